@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// completionMethods are offered when completing the value of --method.
+var completionMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// completionShells are the shells completion scripts are generated for.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// fileFlags are the flags whose value is a path, completed with file names.
+var fileFlags = map[string]bool{"config": true, "request": true}
+
+// completionScript returns the shell completion script for shell ("bash", "zsh" or "fish")
+// covering the flags defined in fs.
+func completionScript(shell string, fs *flag.FlagSet) (string, error) {
+	var names []string
+	usage := map[string]string{}
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, f.Name)
+		usage[f.Name] = f.Usage
+	})
+	sort.Strings(names)
+
+	var b strings.Builder
+	switch shell {
+	case "bash":
+		var files []string
+		for _, name := range names {
+			if fileFlags[name] {
+				files = append(files, "--"+name)
+			}
+		}
+		fmt.Fprintf(&b, "# bash completion for lazypost; load with: source <(lazypost completion bash)\n")
+		fmt.Fprintf(&b, "_lazypost() {\n")
+		fmt.Fprintf(&b, "\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+		fmt.Fprintf(&b, "\tcase \"$prev\" in\n")
+		fmt.Fprintf(&b, "\t%s|open) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n", strings.Join(files, "|"))
+		fmt.Fprintf(&b, "\tcompletion) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", strings.Join(completionShells, " "))
+		fmt.Fprintf(&b, "\t--method) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", strings.Join(completionMethods, " "))
+		fmt.Fprintf(&b, "\tesac\n")
+		fmt.Fprintf(&b, "\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", "--"+strings.Join(names, " --")+" completion open")
+		fmt.Fprintf(&b, "}\n")
+		fmt.Fprintf(&b, "complete -o default -F _lazypost lazypost\n")
+	case "zsh":
+		fmt.Fprintf(&b, "#compdef lazypost\n")
+		fmt.Fprintf(&b, "# zsh completion for lazypost; load with: source <(lazypost completion zsh)\n")
+		fmt.Fprintf(&b, "_lazypost() {\n\tlocal state line\n\t_arguments \\\n")
+		for _, name := range names {
+			action := ""
+			switch {
+			case fileFlags[name]:
+				action = ":file:_files"
+			case name == "method":
+				action = ":method:(" + strings.Join(completionMethods, " ") + ")"
+			case isBoolFlag(fs, name):
+			default:
+				action = ":" + name + ":"
+			}
+			fmt.Fprintf(&b, "\t\t'--%s[%s]%s' \\\n", name, zshEscape(usage[name]), action)
+		}
+		fmt.Fprintf(&b, "\t\t'1:url or command:(completion open)' \\\n")
+		fmt.Fprintf(&b, "\t\t'2: :->second'\n")
+		fmt.Fprintf(&b, "\t[[ $state == second ]] || return\n")
+		fmt.Fprintf(&b, "\tif [[ $line[1] == completion ]]; then _values shell %s; else _files; fi\n}\n", strings.Join(completionShells, " "))
+		fmt.Fprintf(&b, "compdef _lazypost lazypost\n")
+	case "fish":
+		fmt.Fprintf(&b, "# fish completion for lazypost; load with: lazypost completion fish | source\n")
+		fmt.Fprintf(&b, "complete -c lazypost -n __fish_use_subcommand -a completion -d 'Print a shell completion script'\n")
+		fmt.Fprintf(&b, "complete -c lazypost -n __fish_use_subcommand -a open -d 'Open a saved request file, ready to send'\n")
+		fmt.Fprintf(&b, "complete -c lazypost -n '__fish_seen_subcommand_from completion' -x -a '%s'\n", strings.Join(completionShells, " "))
+		fmt.Fprintf(&b, "complete -c lazypost -n '__fish_seen_subcommand_from open' -r -F\n")
+		for _, name := range names {
+			option := ""
+			switch {
+			case fileFlags[name]:
+				option = " -r -F"
+			case name == "method":
+				option = " -x -a '" + strings.Join(completionMethods, " ") + "'"
+			case !isBoolFlag(fs, name):
+				option = " -r"
+			}
+			fmt.Fprintf(&b, "complete -c lazypost -l %s%s -d '%s'\n", name, option, strings.ReplaceAll(usage[name], "'", "\\'"))
+		}
+	default:
+		return "", fmt.Errorf("unsupported shell %q: use bash, zsh or fish", shell)
+	}
+	return b.String(), nil
+}
+
+// isBoolFlag reports whether the flag called name takes no value.
+func isBoolFlag(fs *flag.FlagSet, name string) bool {
+	f := fs.Lookup(name)
+	if f == nil {
+		return false
+	}
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// zshEscape escapes text for use inside a quoted _arguments description.
+func zshEscape(s string) string {
+	return strings.NewReplacer("'", "'\\''", "[", "\\[", "]", "\\]", ":", "\\:").Replace(s)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCompletionScript tests that the script for each shell offers every flag of the
+// command line and the subcommands, and that the bash script parses.
+func TestCompletionScript(t *testing.T) {
+	fs := flag.NewFlagSet("lazypost", flag.ContinueOnError)
+	defineFlags(fs)
+	flags := []string{"config", "request", "read-only", "url", "method", "body", "header", "no-color"}
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			script, err := completionScript(shell, fs)
+			if err != nil {
+				t.Fatalf("completionScript(%q) error = %v", shell, err)
+			}
+			for _, name := range flags {
+				option := "--" + name
+				if shell == "fish" {
+					option = "-l " + name + " "
+				}
+				if !strings.Contains(script, option) {
+					t.Errorf("%s script does not offer --%s:\n%s", shell, name, script)
+				}
+			}
+			for _, command := range []string{"completion", "open"} {
+				if !strings.Contains(script, command) {
+					t.Errorf("%s script does not offer %s:\n%s", shell, command, script)
+				}
+			}
+
+			if shell != "bash" {
+				return
+			}
+			if _, err := exec.LookPath("bash"); err != nil {
+				t.Skip("bash is not installed")
+			}
+			path := filepath.Join(t.TempDir(), "lazypost.bash")
+			if err := os.WriteFile(path, []byte(script), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if out, err := exec.Command("bash", "-n", path).CombinedOutput(); err != nil {
+				t.Errorf("bash -n: %v\n%s", err, out)
+			}
+		})
+	}
+
+	if _, err := completionScript("powershell", fs); err == nil {
+		t.Error("completionScript(\"powershell\") should fail")
+	}
+}
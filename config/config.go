@@ -0,0 +1,108 @@
+// Package config loads the user configuration for the LazyPost application.
+// Settings are read from a JSON file in the user's configuration directory
+// and can be overridden by command line flags.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Config holds the user-configurable settings of LazyPost.
+// The zero value is a valid default configuration.
+type Config struct {
+	NoColor         bool       `json:"no_color"`          // NoColor disables all colors and text styling in the UI.
+	HostRules       []HostRule `json:"host_rules"`        // HostRules add default headers to requests for matching hosts.
+	Redaction       Redaction  `json:"redaction"`         // Redaction controls what is hidden when requests and responses are copied or exported.
+	Vault           Vault      `json:"vault"`             // Vault configures the server used to resolve {{vault:path#key}} placeholders.
+	LatencyBudgetMS int        `json:"latency_budget_ms"` // LatencyBudgetMS flags responses slower than this many milliseconds, 0 for none.
+	Proxy           string     `json:"proxy"`             // Proxy is the proxy URL for all requests; HTTPS_PROXY/HTTP_PROXY are used when empty.
+	NoProxy         []string   `json:"no_proxy"`          // NoProxy lists hosts, domains or CIDR ranges reached directly, in addition to NO_PROXY.
+	ReadOnly        bool       `json:"read_only"`         // ReadOnly disables sending requests and editing them, for demos and reviews.
+	SyntaxTheme     string     `json:"syntax_theme"`      // SyntaxTheme is the color scheme for JSON bodies: "default", "high-contrast" or "none".
+	RequestIDHeader string     `json:"request_id_header"` // RequestIDHeader, e.g. "X-Request-ID", is set to a fresh ID on every send; empty to disable.
+	PrivacyMode     bool       `json:"privacy_mode"`      // PrivacyMode starts LazyPost with secrets masked everywhere in the UI.
+}
+
+// Vault configures the HashiCorp Vault server used for secret placeholders.
+// The token is never stored in the config; it is read from VAULT_TOKEN or ~/.vault-token.
+type Vault struct {
+	Address string `json:"address"` // Address is the Vault server URL; VAULT_ADDR is used when empty.
+}
+
+// Redaction configures the removal of credentials from copied and exported text.
+// Built-in rules for common auth headers and token fields always apply unless Disabled is set;
+// Headers and Patterns add to them.
+type Redaction struct {
+	Disabled bool     `json:"disabled"` // Disabled turns redaction off entirely.
+	Headers  []string `json:"headers"`  // Headers are additional header names whose values are redacted.
+	Patterns []string `json:"patterns"` // Patterns are additional regular expressions; only the first capture group is redacted if there is one.
+}
+
+// HostRule adds default headers and auth to every request whose host matches Host.
+// Headers entered in the UI and the auth panel take precedence over rule values.
+type HostRule struct {
+	Host            string            `json:"host"`              // Host is a host name or glob pattern, e.g. "api.github.com" or "*.example.com".
+	Headers         map[string]string `json:"headers"`           // Headers are added to matching requests.
+	BearerToken     string            `json:"bearer_token"`      // BearerToken, if set, is sent as "Authorization: Bearer <token>".
+	LatencyBudgetMS int               `json:"latency_budget_ms"` // LatencyBudgetMS, if set, overrides the global latency budget for matching hosts.
+	BypassProxy     bool              `json:"bypass_proxy"`      // BypassProxy sends requests to matching hosts directly, without the proxy.
+}
+
+// Matches reports whether the rule applies to host. host must not include a port.
+// Patterns use path.Match syntax and are compared case-insensitively;
+// '*' matches any run of characters, including dots, so "*.example.com" covers all subdomains.
+func (r HostRule) Matches(host string) bool {
+	matched, err := path.Match(strings.ToLower(r.Host), strings.ToLower(host))
+	return err == nil && matched
+}
+
+// DefaultPath returns the default location of the configuration file,
+// e.g. ~/.config/lazypost/config.json on Linux.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "lazypost", "config.json"), nil
+}
+
+// Load reads the configuration from path. If path is empty, DefaultPath is used.
+// A missing file is not an error and yields the default configuration.
+func Load(path string) (Config, error) {
+	var cfg Config
+
+	if path == "" {
+		defaultPath, err := DefaultPath()
+		if err != nil {
+			return cfg, nil // No config directory, nothing to load
+		}
+		path = defaultPath
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	for _, pattern := range cfg.Redaction.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return cfg, fmt.Errorf("parsing config %s: invalid redaction pattern %q: %w", path, pattern, err)
+		}
+	}
+	return cfg, nil
+}
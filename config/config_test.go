@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestHostRuleMatches tests host pattern matching for exact names and globs.
+func TestHostRuleMatches(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		host     string
+		expected bool
+	}{
+		{"api.github.com", "api.github.com", true},
+		{"api.github.com", "API.GitHub.com", true},
+		{"api.github.com", "github.com", false},
+		{"*.example.com", "api.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "a.b.example.com", true}, // '*' also spans dots
+		{"[", "anything", false},                   // Malformed pattern never matches
+	}
+
+	for _, tt := range tests {
+		rule := HostRule{Host: tt.pattern}
+		if got := rule.Matches(tt.host); got != tt.expected {
+			t.Errorf("HostRule{%q}.Matches(%q) = %v, expected %v", tt.pattern, tt.host, got, tt.expected)
+		}
+	}
+}
+
+// TestLoad tests loading a config file, a missing file and a malformed file.
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "config.json")
+	data := `{"no_color": true, "host_rules": [{"host": "api.github.com", "headers": {"Accept": "application/vnd.github+json"}}]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.NoColor || len(cfg.HostRules) != 1 || cfg.HostRules[0].Headers["Accept"] != "application/vnd.github+json" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.json")); err != nil {
+		t.Errorf("expected no error for a missing file, got %v", err)
+	}
+
+	badPath := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(badPath, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(badPath); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+
+	badPatternPath := filepath.Join(dir, "bad_pattern.json")
+	if err := os.WriteFile(badPatternPath, []byte(`{"redaction": {"patterns": ["("]}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(badPatternPath); err == nil {
+		t.Error("expected an error for an invalid redaction pattern")
+	}
+}
@@ -0,0 +1,188 @@
+// Package diff computes line-based differences between two texts.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change.
+const contextLines = 3
+
+// maxCells bounds the size of the comparison table. Larger inputs are compared by
+// trimming the common prefix and suffix only, reporting the rest as replaced.
+const maxCells = 4_000_000
+
+// Kind classifies a line in a diff.
+type Kind int
+
+const (
+	Equal   Kind = iota // Line is present in both texts
+	Removed             // Line is only in the first text
+	Added               // Line is only in the second text
+)
+
+// Line is a single line of a diff.
+type Line struct {
+	Kind Kind   // Kind tells whether the line was kept, removed or added
+	Text string // Text is the line without its trailing newline
+}
+
+// Lines returns the line-by-line difference that turns a into b.
+func Lines(a, b string) []Line {
+	aLines := splitLines(a)
+	bLines := splitLines(b)
+
+	// Common prefix and suffix are kept as-is and don't need the comparison table
+	prefix := 0
+	for prefix < len(aLines) && prefix < len(bLines) && aLines[prefix] == bLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(aLines)-prefix && suffix < len(bLines)-prefix &&
+		aLines[len(aLines)-1-suffix] == bLines[len(bLines)-1-suffix] {
+		suffix++
+	}
+
+	var result []Line
+	for _, line := range aLines[:prefix] {
+		result = append(result, Line{Kind: Equal, Text: line})
+	}
+	result = append(result, middle(aLines[prefix:len(aLines)-suffix], bLines[prefix:len(bLines)-suffix])...)
+	for _, line := range aLines[len(aLines)-suffix:] {
+		result = append(result, Line{Kind: Equal, Text: line})
+	}
+	return result
+}
+
+// middle diffs the differing middle sections using a longest common subsequence table.
+func middle(a, b []string) []Line {
+	var result []Line
+
+	if len(a)*len(b) > maxCells {
+		for _, line := range a {
+			result = append(result, Line{Kind: Removed, Text: line})
+		}
+		for _, line := range b {
+			result = append(result, Line{Kind: Added, Text: line})
+		}
+		return result
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, Line{Kind: Equal, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, Line{Kind: Removed, Text: a[i]})
+			i++
+		default:
+			result = append(result, Line{Kind: Added, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		result = append(result, Line{Kind: Removed, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		result = append(result, Line{Kind: Added, Text: b[j]})
+	}
+	return result
+}
+
+// Unified returns a unified diff of a and b labelled with fromName and toName,
+// or an empty string when the texts are identical.
+func Unified(fromName, toName, a, b string) string {
+	lines := Lines(a, b)
+
+	// Mark the lines to print: every change plus its surrounding context
+	show := make([]bool, len(lines))
+	changed := false
+	for i, line := range lines {
+		if line.Kind == Equal {
+			continue
+		}
+		changed = true
+		for k := max(0, i-contextLines); k <= min(len(lines)-1, i+contextLines); k++ {
+			show[k] = true
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+
+	aLine, bLine := 1, 1
+	for i := 0; i < len(lines); {
+		if !show[i] {
+			if lines[i].Kind != Added {
+				aLine++
+			}
+			if lines[i].Kind != Removed {
+				bLine++
+			}
+			i++
+			continue
+		}
+
+		// Collect a hunk of consecutive shown lines
+		end := i
+		aCount, bCount := 0, 0
+		for end < len(lines) && show[end] {
+			if lines[end].Kind != Added {
+				aCount++
+			}
+			if lines[end].Kind != Removed {
+				bCount++
+			}
+			end++
+		}
+
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", aLine, aCount, bLine, bCount)
+		for _, line := range lines[i:end] {
+			switch line.Kind {
+			case Equal:
+				out.WriteString(" ")
+			case Removed:
+				out.WriteString("-")
+			case Added:
+				out.WriteString("+")
+			}
+			out.WriteString(line.Text)
+			out.WriteString("\n")
+		}
+
+		aLine += aCount
+		bLine += bCount
+		i = end
+	}
+	return out.String()
+}
+
+// splitLines splits text into lines, ignoring a single trailing newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,48 @@
+package diff
+
+import "testing"
+
+// TestUnified tests unified diff output for identical, changed and extended texts.
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected string
+	}{
+		{
+			name:     "Identical",
+			a:        "one\ntwo\n",
+			b:        "one\ntwo\n",
+			expected: "",
+		},
+		{
+			name:     "Changed line",
+			a:        "one\ntwo\nthree\n",
+			b:        "one\n2\nthree\n",
+			expected: "--- a\n+++ b\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n",
+		},
+		{
+			name:     "Added line",
+			a:        "one\n",
+			b:        "one\ntwo\n",
+			expected: "--- a\n+++ b\n@@ -1,1 +1,2 @@\n one\n+two\n",
+		},
+		{
+			name: "Separate hunks",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			b:    "x\n2\n3\n4\n5\n6\n7\n8\n9\ny\n",
+			expected: "--- a\n+++ b\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n" +
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+y\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("a", "b", tt.a, tt.b); got != tt.expected {
+				t.Errorf("Unified() =\n%s\nexpected\n%s", got, tt.expected)
+			}
+		})
+	}
+}
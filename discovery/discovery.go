@@ -0,0 +1,130 @@
+// Package discovery finds HTTP services running on the local machine, so they can be
+// offered as request targets during local API development.
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPorts are the ports commonly used by local development servers.
+var DefaultPorts = []int{
+	3000, 3001, 4000, 4200, 5000, 5001, 5173, 8000, 8008, 8080, 8081, 8443, 8888, 9000, 9090,
+}
+
+// Service is a discovered local service.
+type Service struct {
+	Name string // Name describes the service, e.g. a container name or "port 8080".
+	Port int    // Port is the local TCP port the service listens on.
+	URL  string // URL is the base URL to send requests to.
+}
+
+// dockerPortPattern matches a published TCP port in `docker ps` output, e.g. "0.0.0.0:8080->80/tcp".
+var dockerPortPattern = regexp.MustCompile(`(?:[0-9.]+|\[::\]|::):(\d+)->\d+/tcp`)
+
+// Discover scans the default ports on localhost and lists published Docker container ports.
+// Docker is optional; if it is not installed or not running only the port scan is used.
+// Services are returned sorted by port, one per port.
+func Discover(ctx context.Context, timeout time.Duration) []Service {
+	byPort := make(map[int]Service)
+	for _, service := range ScanPorts("127.0.0.1", DefaultPorts, timeout) {
+		byPort[service.Port] = service
+	}
+
+	// Container names are more descriptive than a bare port, so they take precedence
+	if services, err := DockerServices(ctx); err == nil {
+		for _, service := range services {
+			byPort[service.Port] = service
+		}
+	}
+
+	services := make([]Service, 0, len(byPort))
+	for _, service := range byPort {
+		services = append(services, service)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Port < services[j].Port })
+	return services
+}
+
+// ScanPorts returns the ports on host that accept TCP connections within timeout.
+// Ports are probed concurrently.
+func ScanPorts(host string, ports []int, timeout time.Duration) []Service {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		services []Service
+	)
+
+	for _, port := range ports {
+		wg.Add(1)
+		go func(port int) {
+			defer wg.Done()
+			conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), timeout)
+			if err != nil {
+				return
+			}
+			conn.Close()
+
+			mu.Lock()
+			services = append(services, Service{
+				Name: fmt.Sprintf("port %d", port),
+				Port: port,
+				URL:  localURL(port),
+			})
+			mu.Unlock()
+		}(port)
+	}
+	wg.Wait()
+
+	sort.Slice(services, func(i, j int) bool { return services[i].Port < services[j].Port })
+	return services
+}
+
+// DockerServices lists the TCP ports published by running Docker containers.
+func DockerServices(ctx context.Context) ([]Service, error) {
+	out, err := exec.CommandContext(ctx, "docker", "ps", "--format", "{{.Names}}\t{{.Ports}}").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseDockerPS(string(out)), nil
+}
+
+// parseDockerPS parses `docker ps --format "{{.Names}}\t{{.Ports}}"` output.
+// A port published on both IPv4 and IPv6 is reported once.
+func parseDockerPS(output string) []Service {
+	var services []Service
+	seen := make(map[int]bool)
+
+	for _, line := range strings.Split(output, "\n") {
+		name, ports, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+		for _, match := range dockerPortPattern.FindAllStringSubmatch(ports, -1) {
+			port, err := strconv.Atoi(match[1])
+			if err != nil || seen[port] {
+				continue
+			}
+			seen[port] = true
+			services = append(services, Service{Name: name, Port: port, URL: localURL(port)})
+		}
+	}
+	return services
+}
+
+// localURL returns the base URL for a port on localhost, using https for the usual TLS ports.
+func localURL(port int) string {
+	scheme := "http"
+	if port == 443 || port == 8443 {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://localhost:%d", scheme, port)
+}
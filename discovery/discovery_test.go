@@ -0,0 +1,48 @@
+package discovery
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// TestParseDockerPS tests extracting published ports from docker ps output.
+func TestParseDockerPS(t *testing.T) {
+	output := "api\t0.0.0.0:8080->80/tcp, [::]:8080->80/tcp\n" +
+		"db\t5432/tcp\n" +
+		"proxy\t0.0.0.0:8443->443/tcp, 0.0.0.0:9000->9000/udp\n"
+
+	services := parseDockerPS(output)
+	if len(services) != 2 {
+		t.Fatalf("expected 2 services, got %+v", services)
+	}
+	if services[0] != (Service{Name: "api", Port: 8080, URL: "http://localhost:8080"}) {
+		t.Errorf("unexpected service: %+v", services[0])
+	}
+	if services[1] != (Service{Name: "proxy", Port: 8443, URL: "https://localhost:8443"}) {
+		t.Errorf("unexpected service: %+v", services[1])
+	}
+}
+
+// TestScanPorts tests that only listening ports are reported.
+func TestScanPorts(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	open := listener.Addr().(*net.TCPAddr).Port
+
+	// Find a port that is not listening by opening and closing a listener
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := closedListener.Addr().(*net.TCPAddr).Port
+	closedListener.Close()
+
+	services := ScanPorts("127.0.0.1", []int{open, closed}, time.Second)
+	if len(services) != 1 || services[0].Port != open {
+		t.Errorf("expected only port %d, got %+v", open, services)
+	}
+}
@@ -0,0 +1,180 @@
+// Package env manages environments: named sets of variables that are substituted
+// into requests wherever a {{name}} placeholder appears.
+// Environments are stored in a JSON file next to the configuration file.
+package env
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"unicode/utf8"
+)
+
+// namePattern matches valid variable names.
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// placeholderPattern matches {{name}} placeholders. Names may contain letters, digits,
+// '_', '-' and '.', so Vault references such as {{vault:path#key}} never match.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+// secretMask is shown in place of secret variable values.
+const secretMask = "••••••••"
+
+// Variable is a single named value of an environment.
+type Variable struct {
+	Name   string `json:"name"`   // Name is referenced as {{name}}.
+	Value  string `json:"value"`  // Value replaces the placeholder.
+	Secret bool   `json:"secret"` // Secret masks the value wherever it is displayed.
+}
+
+// Environment is a named, ordered list of variables, e.g. "dev" or "production".
+type Environment struct {
+	Name      string     `json:"name"`      // Name identifies the environment.
+	Variables []Variable `json:"variables"` // Variables in display order.
+}
+
+// Store holds all environments and which one is selected.
+// The zero value is a valid, empty store.
+type Store struct {
+	Active       string        `json:"active"`       // Active is the name of the selected environment, empty for none.
+	Environments []Environment `json:"environments"` // Environments in display order.
+}
+
+// DefaultPath returns the default location of the environments file,
+// e.g. ~/.config/lazypost/environments.json on Linux.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "lazypost", "environments.json"), nil
+}
+
+// Load reads the environments from path. A missing file is not an error and yields an empty store.
+func Load(path string) (Store, error) {
+	var store Store
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return store, fmt.Errorf("reading environments %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &store); err != nil {
+		return store, fmt.Errorf("parsing environments %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Save writes the environments to path, creating its directory if needed.
+// The file is only readable by the user, since it may hold secrets.
+func (s Store) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("saving environments %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("saving environments %s: %w", path, err)
+	}
+	return nil
+}
+
+// ValidName reports whether name can be used as a variable name in a {{name}} placeholder.
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Current returns the selected environment, or nil if none is selected or it no longer exists.
+func (s Store) Current() *Environment {
+	return s.Find(s.Active)
+}
+
+// Find returns the environment called name, or nil if there is none.
+func (s Store) Find(name string) *Environment {
+	if name == "" {
+		return nil
+	}
+	for i := range s.Environments {
+		if s.Environments[i].Name == name {
+			return &s.Environments[i]
+		}
+	}
+	return nil
+}
+
+// Lookup returns the variable called name. It is safe to call on a nil environment.
+func (e *Environment) Lookup(name string) (Variable, bool) {
+	if e == nil {
+		return Variable{}, false
+	}
+	for _, v := range e.Variables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variable{}, false
+}
+
+// Expand replaces every {{name}} placeholder in s with the value of the variable.
+// Placeholders without a matching variable are left as they are.
+func (e *Environment) Expand(s string) string {
+	if e == nil {
+		return s
+	}
+	return placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		if v, ok := e.Lookup(name); ok {
+			return v.Value
+		}
+		return match
+	})
+}
+
+// Placeholders returns the variable names referenced in s, in order of appearance.
+func Placeholders(s string) []string {
+	var names []string
+	for _, m := range placeholderPattern.FindAllStringSubmatch(s, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// PlaceholderAt returns the name of the placeholder under the cursor, if any.
+// cursor is a rune offset into s, as reported by text inputs; a cursor just past
+// the closing braces still counts as being on the placeholder.
+func PlaceholderAt(s string, cursor int) (string, bool) {
+	offset := byteOffset(s, cursor)
+	for _, m := range placeholderPattern.FindAllStringSubmatchIndex(s, -1) {
+		if offset >= m[0] && offset <= m[1] {
+			return s[m[2]:m[3]], true
+		}
+	}
+	return "", false
+}
+
+// DisplayValue returns the value to show for v, masked if it is a secret.
+func (v Variable) DisplayValue() string {
+	if v.Secret {
+		return secretMask
+	}
+	return v.Value
+}
+
+// byteOffset converts a rune offset into s to a byte offset, clamped to the length of s.
+func byteOffset(s string, runes int) int {
+	offset := 0
+	for i := 0; i < runes && offset < len(s); i++ {
+		_, size := utf8.DecodeRuneInString(s[offset:])
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,110 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestExpand checks that known placeholders are substituted and others are kept.
+func TestExpand(t *testing.T) {
+	e := &Environment{Name: "dev", Variables: []Variable{
+		{Name: "baseUrl", Value: "https://dev.example.com"},
+		{Name: "token", Value: "s3cret", Secret: true},
+	}}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"No placeholders", "https://example.com", "https://example.com"},
+		{"Known variable", "{{baseUrl}}/users", "https://dev.example.com/users"},
+		{"Spaces inside braces", "Bearer {{ token }}", "Bearer s3cret"},
+		{"Unknown variable kept", "{{basUrl}}/users", "{{basUrl}}/users"},
+		{"Vault placeholder kept", "{{vault:secret/data/x#token}}", "{{vault:secret/data/x#token}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.Expand(tt.input); got != tt.expected {
+				t.Errorf("Expand(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	var none *Environment
+	if got := none.Expand("{{baseUrl}}"); got != "{{baseUrl}}" {
+		t.Errorf("nil environment changed the input to %q", got)
+	}
+}
+
+// TestPlaceholderAt checks cursor positions inside, at the edges of and outside a placeholder.
+func TestPlaceholderAt(t *testing.T) {
+	s := "é{{host}}/x"
+	tests := []struct {
+		cursor   int
+		wantName string
+		wantOK   bool
+	}{
+		{0, "", false},
+		{1, "host", true},
+		{5, "host", true},
+		{9, "host", true},
+		{10, "", false},
+	}
+
+	for _, tt := range tests {
+		name, ok := PlaceholderAt(s, tt.cursor)
+		if name != tt.wantName || ok != tt.wantOK {
+			t.Errorf("PlaceholderAt(%q, %d) = %q, %v, want %q, %v", s, tt.cursor, name, ok, tt.wantName, tt.wantOK)
+		}
+	}
+}
+
+// TestPlaceholders checks that names are returned in order of appearance.
+func TestPlaceholders(t *testing.T) {
+	got := Placeholders("{{a}}/{{ b }}/{{vault:x#y}}/{{a}}")
+	want := []string{"a", "b", "a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Placeholders() = %v, want %v", got, want)
+	}
+}
+
+// TestLoad checks loading a store and selecting the active environment.
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := Load(filepath.Join(dir, "missing.json"))
+	if err != nil || store.Current() != nil {
+		t.Fatalf("missing file: got %+v, %v, want an empty store", store, err)
+	}
+
+	path := filepath.Join(dir, "environments.json")
+	data := `{"active": "dev", "environments": [{"name": "dev", "variables": [{"name": "host", "value": "localhost"}]}]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if v, ok := store.Current().Lookup("host"); !ok || v.Value != "localhost" {
+		t.Errorf("Lookup(host) = %+v, %v, want localhost", v, ok)
+	}
+
+	// Saving and loading again keeps the store intact
+	savedPath := filepath.Join(dir, "nested", "environments.json")
+	if err := store.Save(savedPath); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	reloaded, err := Load(savedPath)
+	if err != nil {
+		t.Fatalf("Load() after Save() error = %v", err)
+	}
+	if !reflect.DeepEqual(reloaded, store) {
+		t.Errorf("reloaded store = %+v, want %+v", reloaded, store)
+	}
+}
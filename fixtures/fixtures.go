@@ -0,0 +1,233 @@
+// Package fixtures provides a deterministic HTTP server for tests of request features.
+// Each route exercises one behavior (echoing the request, status codes, redirects,
+// compression, Retry-After, auth challenges, slow responses), so tests for new features
+// can send real requests without depending on the network or hand-written handlers.
+package fixtures
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// GzipBody is the decompressed body served by the /gzip route.
+const GzipBody = "hello, compressed world"
+
+// Request is a request received by the server.
+type Request struct {
+	Method  string      // Method is the HTTP method, e.g. "POST".
+	Host    string      // Host is the host the request was addressed to, from the Host header.
+	Path    string      // Path is the URL path, e.g. "/echo".
+	Query   string      // Query is the raw query string, without "?".
+	Header  http.Header // Header holds the request headers.
+	Body    []byte      // Body is the full request body.
+	Trailer http.Header // Trailer holds the trailers sent after the body, if any.
+}
+
+// Echo is the JSON document returned by the /echo route.
+type Echo struct {
+	Method string              `json:"method"`  // Method is the HTTP method.
+	Path   string              `json:"path"`    // Path is the URL path.
+	Query  string              `json:"query"`   // Query is the raw query string.
+	Header map[string][]string `json:"headers"` // Header holds the request headers.
+	Body   string              `json:"body"`    // Body is the request body.
+}
+
+// Server is a test HTTP server that records every request it receives.
+//
+// Routes:
+//
+//	/echo                    the request as an Echo document; X-Request-ID is echoed in the response
+//	/status/{code}           an empty response with the given status code
+//	/redirect/{n}            n redirects before arriving at /echo
+//	/redirect-loop           redirects to itself forever
+//	/gzip                    GzipBody, gzip-encoded whatever the Accept-Encoding
+//	/retry-after/{seconds}   429 Too Many Requests with a Retry-After header
+//	/bearer/{token}          200 if "Authorization: Bearer {token}" is sent, 401 otherwise
+//	/basic/{user}/{password} 200 if the Basic credentials match, 401 otherwise
+//	/delay/{ms}              200 after the delay, or nothing if the request is canceled first
+//	/informational           102 Processing and 103 Early Hints with a Link header, then 200
+//	/trailers                the request trailers sent back as response trailers, plus Grpc-Status: 0
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex // mu guards requests.
+	requests []Request  // requests are the requests received, in order.
+}
+
+// NewServer starts a fixtures server that is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{}
+	s.Server = httptest.NewServer(s.routes())
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// LastRequest returns the most recent request, and false if none was received.
+func (s *Server) LastRequest() (Request, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return Request{}, false
+	}
+	return s.requests[len(s.requests)-1], true
+}
+
+// routes returns the handler for all routes, recording each request first.
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", handleEcho)
+	mux.HandleFunc("/status/{code}", handleStatus)
+	mux.HandleFunc("/redirect/{n}", handleRedirect)
+	mux.HandleFunc("/redirect-loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Path, http.StatusFound)
+	})
+	mux.HandleFunc("/gzip", handleGzip)
+	mux.HandleFunc("/retry-after/{seconds}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", r.PathValue("seconds"))
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("/bearer/{token}", func(w http.ResponseWriter, r *http.Request) {
+		authorize(w, r.Header.Get("Authorization") == "Bearer "+r.PathValue("token"), `Bearer realm="fixtures"`)
+	})
+	mux.HandleFunc("/basic/{user}/{password}", func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		authorize(w, ok && user == r.PathValue("user") && password == r.PathValue("password"), `Basic realm="fixtures"`)
+	})
+	mux.HandleFunc("/delay/{ms}", handleDelay)
+	mux.HandleFunc("/trailers", handleTrailers)
+	mux.HandleFunc("/informational", handleInformational)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method:  r.Method,
+			Host:    r.Host,
+			Path:    r.URL.Path,
+			Query:   r.URL.RawQuery,
+			Header:  r.Header.Clone(),
+			Body:    body,
+			Trailer: r.Trailer.Clone(),
+		})
+		s.mu.Unlock()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// handleEcho returns the request as an Echo document.
+func handleEcho(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if id := r.Header.Get("X-Request-ID"); id != "" {
+		w.Header().Set("X-Request-ID", id)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Echo{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.RawQuery,
+		Header: r.Header,
+		Body:   string(body),
+	})
+}
+
+// handleStatus responds with the status code in the path.
+func handleStatus(w http.ResponseWriter, r *http.Request) {
+	code, err := strconv.Atoi(r.PathValue("code"))
+	if err != nil || code < 100 || code > 999 {
+		http.Error(w, "invalid status code", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(code)
+}
+
+// handleRedirect redirects n times before arriving at /echo.
+func handleRedirect(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil || n < 0 {
+		http.Error(w, "invalid redirect count", http.StatusBadRequest)
+		return
+	}
+	target := "/echo"
+	if n > 1 {
+		target = fmt.Sprintf("/redirect/%d", n-1)
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// handleGzip serves GzipBody gzip-encoded, even to clients that did not ask for it.
+func handleGzip(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.Header().Set("Content-Encoding", "gzip")
+	zw := gzip.NewWriter(w)
+	io.WriteString(zw, GzipBody)
+	zw.Close()
+}
+
+// handleDelay responds after the delay in the path, unless the request is canceled first.
+func handleDelay(w http.ResponseWriter, r *http.Request) {
+	ms, err := strconv.Atoi(r.PathValue("ms"))
+	if err != nil || ms < 0 {
+		http.Error(w, "invalid delay", http.StatusBadRequest)
+		return
+	}
+	select {
+	case <-time.After(time.Duration(ms) * time.Millisecond):
+		w.WriteHeader(http.StatusOK)
+	case <-r.Context().Done():
+	}
+}
+
+// handleInformational sends 102 Processing and 103 Early Hints before the final response.
+func handleInformational(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusProcessing)
+	w.Header().Set("Link", "</style.css>; rel=preload; as=style")
+	w.WriteHeader(http.StatusEarlyHints)
+	w.Header().Del("Link")
+	io.WriteString(w, "ok")
+}
+
+// handleTrailers sends the request trailers back as response trailers after a short body,
+// with a gRPC-Web style status trailer.
+func handleTrailers(w http.ResponseWriter, r *http.Request) {
+	// The body, and with it the trailers, was read when the request was recorded
+	names := []string{"Grpc-Status"}
+	for name := range r.Trailer {
+		names = append(names, name)
+	}
+	w.Header().Set("Trailer", strings.Join(names, ", "))
+	io.WriteString(w, "ok")
+
+	w.Header().Set("Grpc-Status", "0")
+	for name, values := range r.Trailer {
+		w.Header()[name] = values
+	}
+}
+
+// authorize responds 200 if ok, otherwise 401 with the given challenge.
+func authorize(w http.ResponseWriter, ok bool, challenge string) {
+	if !ok {
+		w.Header().Set("WWW-Authenticate", challenge)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,72 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestEcho tests that /echo returns the request and that requests are recorded.
+func TestEcho(t *testing.T) {
+	s := NewServer(t)
+
+	req, _ := http.NewRequest("POST", s.URL+"/echo?page=2", strings.NewReader("payload"))
+	req.Header.Set("X-Request-ID", "abc")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var echo Echo
+	if err := json.NewDecoder(resp.Body).Decode(&echo); err != nil {
+		t.Fatalf("decoding echo: %v", err)
+	}
+	if echo.Method != "POST" || echo.Query != "page=2" || echo.Body != "payload" {
+		t.Errorf("got echo %+v", echo)
+	}
+	if got := resp.Header.Get("X-Request-ID"); got != "abc" {
+		t.Errorf("X-Request-ID = %q, want it echoed", got)
+	}
+
+	last, ok := s.LastRequest()
+	if !ok || last.Path != "/echo" || string(last.Body) != "payload" {
+		t.Errorf("recorded %+v, want the /echo request with its body", last)
+	}
+}
+
+// TestRoutes tests the status codes of the other routes.
+func TestRoutes(t *testing.T) {
+	s := NewServer(t)
+
+	tests := []struct {
+		path   string
+		header map[string]string
+		want   int
+	}{
+		{"/status/418", nil, 418},
+		{"/redirect/3", nil, http.StatusOK},
+		{"/retry-after/5", nil, http.StatusTooManyRequests},
+		{"/bearer/t0ken", map[string]string{"Authorization": "Bearer t0ken"}, http.StatusOK},
+		{"/bearer/t0ken", nil, http.StatusUnauthorized},
+		{"/delay/1", nil, http.StatusOK},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest("GET", s.URL+tt.path, nil)
+		for name, value := range tt.header {
+			req.Header.Set(name, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.path, resp.StatusCode, tt.want)
+		}
+	}
+	if n := len(s.Requests()); n != len(tests)+3 {
+		t.Errorf("recorded %d requests, want %d including the redirects", n, len(tests)+3)
+	}
+}
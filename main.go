@@ -1,15 +1,185 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/env"
+	"github.com/RAshkettle/LazyPost/request"
 	"github.com/RAshkettle/LazyPost/ui"
+	"github.com/RAshkettle/LazyPost/ui/styles"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// headerFlags collects the values of a repeatable --header flag.
+type headerFlags []string
+
+// String returns the headers given so far.
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ", ")
+}
+
+// Set adds a header given as "Name: value".
+func (h *headerFlags) Set(value string) error {
+	if _, _, err := request.ParseHeader(value); err != nil {
+		return err
+	}
+	*h = append(*h, value)
+	return nil
+}
+
+// options holds the values of the command line flags.
+type options struct {
+	configPath  string      // Config file to load, empty for the default location
+	noColor     bool        // Whether colors and text styling are disabled
+	requestFile string      // Exported request file to load into the form
+	readOnly    bool        // Whether sending and editing are disabled
+	url         string      // URL to pre-fill
+	method      string      // Method to pre-fill
+	body        string      // Body to pre-fill, or @file to read it from a file
+	headers     headerFlags // Headers to pre-fill, as "Name: value"
+}
+
+// defineFlags defines the command line flags on fs and returns the options they set.
+func defineFlags(fs *flag.FlagSet) *options {
+	o := &options{}
+	fs.StringVar(&o.configPath, "config", "", "path to the config file (default: user config dir/lazypost/config.json)")
+	fs.BoolVar(&o.noColor, "no-color", false, "disable all colors and text styling")
+	fs.StringVar(&o.requestFile, "request", "", "load an exported request file into the form")
+	fs.BoolVar(&o.readOnly, "read-only", false, "browse requests and responses without sending or editing")
+	fs.StringVar(&o.url, "url", "", "pre-fill the request URL (may also be given as the only argument)")
+	fs.StringVar(&o.method, "method", "", "pre-fill the request method, e.g. POST")
+	fs.StringVar(&o.body, "body", "", "pre-fill the request body; @file reads it from a file")
+	fs.Var(&o.headers, "header", "pre-fill a request header as \"Name: value\" (repeatable)")
+	return o
+}
+
 func main() {
-	app := ui.NewApp()
+	opts := defineFlags(flag.CommandLine)
+	flag.Parse()
+
+	if flag.Arg(0) == "completion" {
+		script, err := completionScript(flag.Arg(1), flag.CommandLine)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(script)
+		return
+	}
+	// "open <file>" loads a saved request and focuses it, ready to send
+	opened := flag.Arg(0) == "open"
+	if opened {
+		if flag.NArg() != 2 {
+			fmt.Println("Error: usage: lazypost [flags] open <request file>")
+			os.Exit(1)
+		}
+		if opts.requestFile != "" {
+			fmt.Println("Error: the request file is given both to open and with --request")
+			os.Exit(1)
+		}
+		opts.requestFile = flag.Arg(1)
+	} else if flag.NArg() > 1 {
+		fmt.Printf("Error: expected at most one URL argument, got %d\n", flag.NArg())
+		os.Exit(1)
+	}
+	if flag.NArg() == 1 {
+		if opts.url != "" {
+			fmt.Println("Error: the URL is given both as an argument and with --url")
+			os.Exit(1)
+		}
+		opts.url = flag.Arg(0)
+	}
+
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Command line flags take precedence over the config file
+	if opts.noColor {
+		cfg.NoColor = true
+	}
+	if opts.readOnly {
+		cfg.ReadOnly = true
+	}
+
+	// Pick colors the terminal can display; NO_COLOR is honored by DetectProfile
+	if cfg.NoColor {
+		styles.DisableColor()
+	} else {
+		styles.ApplyProfile(styles.DetectProfile())
+	}
+	if err := styles.UseSyntaxTheme(cfg.SyntaxTheme); err != nil {
+		// The error names the theme and the valid ones, e.g. unknown syntax theme "dark": use one of ...
+		fmt.Printf("Error in the syntax_theme setting: %v\n", err)
+		os.Exit(1)
+	}
+
+	app := ui.NewApp(cfg)
+
+	// Environments live next to the config file; without a config directory there are none
+	if envPath, err := env.DefaultPath(); err == nil {
+		store, err := env.Load(envPath)
+		if err != nil {
+			fmt.Printf("Error loading environments: %v\n", err)
+			os.Exit(1)
+		}
+		app.SetEnvironments(store, envPath)
+	}
+
+	// The request file, if any, is the starting point; the other request flags override it
+	var r request.Request
+	prefill := false
+	if opts.requestFile != "" {
+		r, err = request.LoadFile(opts.requestFile)
+		if err != nil {
+			fmt.Printf("Error loading request: %v\n", err)
+			os.Exit(1)
+		}
+		prefill = true
+	}
+	if opts.url != "" {
+		r.URL = opts.url
+		prefill = true
+	}
+	if opts.method != "" {
+		r.Method = strings.ToUpper(opts.method)
+		prefill = true
+	}
+	if opts.body != "" {
+		r.Body, err = request.ReadBody(opts.body)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		prefill = true
+	}
+	for _, header := range opts.headers {
+		name, value, _ := request.ParseHeader(header) // Validated by headerFlags.Set
+		if r.Headers == nil {
+			r.Headers = map[string]string{}
+		}
+		r.Headers[name] = value
+		prefill = true
+	}
+	load := app.LoadRequest
+	if opened {
+		load = app.OpenRequest
+	}
+	if prefill {
+		for _, warning := range load(r) {
+			fmt.Printf("Warning: %s\n", warning)
+		}
+	}
+	// A body read from a file is reloaded from it in watch mode
+	if path, isFile := strings.CutPrefix(opts.body, "@"); isFile {
+		app.SetBodyFile(path)
+	}
 	p := tea.NewProgram(app, tea.WithAltScreen())
 
 	if _, err := p.Run(); err != nil {
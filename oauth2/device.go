@@ -0,0 +1,154 @@
+// Package oauth2 implements the client side of the OAuth 2.0 device authorization grant
+// (RFC 8628), which lets a terminal application obtain a token without a browser callback.
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// deviceCodeGrantType is the grant_type sent when polling the token endpoint.
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// Errors returned by PollToken while the user has not finished authorizing the device.
+var (
+	ErrAuthorizationPending = errors.New("authorization pending")                      // Poll again after the interval
+	ErrSlowDown             = errors.New("polling too fast, interval increased by 5s") // Poll again after a longer interval
+)
+
+// DeviceConfig describes the authorization server and client used for the device flow.
+type DeviceConfig struct {
+	DeviceAuthURL string // DeviceAuthURL is the device authorization endpoint.
+	TokenURL      string // TokenURL is the token endpoint.
+	ClientID      string // ClientID identifies the application to the server.
+	Scope         string // Scope is a space-separated list of requested scopes, may be empty.
+}
+
+// DeviceCode is the response of the device authorization endpoint.
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`               // DeviceCode is sent back when polling for the token.
+	UserCode                string `json:"user_code"`                 // UserCode is entered by the user on the verification page.
+	VerificationURI         string `json:"verification_uri"`          // VerificationURI is the page where the user enters the code.
+	VerificationURIComplete string `json:"verification_uri_complete"` // VerificationURIComplete, if set, already includes the user code.
+	ExpiresIn               int    `json:"expires_in"`                // ExpiresIn is the lifetime of the codes in seconds.
+	Interval                int    `json:"interval"`                  // Interval is the minimum number of seconds between polls.
+}
+
+// Token is an access token issued by the token endpoint.
+type Token struct {
+	AccessToken  string `json:"access_token"`  // AccessToken is sent with requests.
+	TokenType    string `json:"token_type"`    // TokenType is usually "Bearer".
+	RefreshToken string `json:"refresh_token"` // RefreshToken, if issued, can renew the access token.
+	ExpiresIn    int    `json:"expires_in"`    // ExpiresIn is the lifetime of the access token in seconds.
+}
+
+// errorResponse is the error body defined by RFC 6749 section 5.2.
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// RequestDeviceCode starts a device authorization flow.
+func RequestDeviceCode(ctx context.Context, client *http.Client, cfg DeviceConfig) (DeviceCode, error) {
+	form := url.Values{"client_id": {cfg.ClientID}}
+	if cfg.Scope != "" {
+		form.Set("scope", cfg.Scope)
+	}
+
+	var code DeviceCode
+	body, err := postForm(ctx, client, cfg.DeviceAuthURL, form)
+	if err != nil {
+		return code, fmt.Errorf("device authorization: %w", err)
+	}
+	if err := json.Unmarshal(body, &code); err != nil {
+		return code, fmt.Errorf("device authorization: %w", err)
+	}
+
+	// Some providers use the draft name verification_url
+	if code.VerificationURI == "" {
+		var legacy struct {
+			VerificationURL string `json:"verification_url"`
+		}
+		if json.Unmarshal(body, &legacy) == nil {
+			code.VerificationURI = legacy.VerificationURL
+		}
+	}
+
+	if code.DeviceCode == "" || code.UserCode == "" {
+		return code, errors.New("device authorization: response has no device or user code")
+	}
+	if code.Interval <= 0 {
+		code.Interval = 5 // Default from RFC 8628 section 3.2
+	}
+	return code, nil
+}
+
+// PollToken asks the token endpoint once whether the user has authorized the device.
+// It returns ErrAuthorizationPending or ErrSlowDown while authorization is still in progress.
+func PollToken(ctx context.Context, client *http.Client, cfg DeviceConfig, deviceCode string) (Token, error) {
+	form := url.Values{
+		"grant_type":  {deviceCodeGrantType},
+		"device_code": {deviceCode},
+		"client_id":   {cfg.ClientID},
+	}
+
+	var token Token
+	body, err := postForm(ctx, client, cfg.TokenURL, form)
+	if err != nil {
+		return token, fmt.Errorf("token request: %w", err)
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return token, fmt.Errorf("token request: %w", err)
+	}
+	if token.AccessToken == "" {
+		return token, errors.New("token request: response has no access token")
+	}
+	return token, nil
+}
+
+// postForm posts form to endpoint and returns the response body of a successful request.
+// OAuth error responses are converted to errors, including those sent with status 200.
+func postForm(ctx context.Context, client *http.Client, endpoint string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var oauthErr errorResponse
+	if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error != "" {
+		switch oauthErr.Error {
+		case "authorization_pending":
+			return nil, ErrAuthorizationPending
+		case "slow_down":
+			return nil, ErrSlowDown
+		}
+		if oauthErr.ErrorDescription != "" {
+			return nil, fmt.Errorf("%s: %s", oauthErr.Error, oauthErr.ErrorDescription)
+		}
+		return nil, errors.New(oauthErr.Error)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return body, nil
+}
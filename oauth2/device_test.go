@@ -0,0 +1,81 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestDeviceFlow tests requesting a device code and polling until the token is issued.
+func TestDeviceFlow(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("client_id") != "cli" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/device":
+			w.Write([]byte(`{"device_code":"dev","user_code":"ABCD-EFGH","verification_url":"https://example.com/device","expires_in":600}`))
+		case "/token":
+			if r.Form.Get("grant_type") != deviceCodeGrantType || r.Form.Get("device_code") != "dev" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			polls++
+			switch polls {
+			case 1:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"authorization_pending"}`))
+			case 2:
+				// Some providers report errors with status 200
+				w.Write([]byte(`{"error":"slow_down"}`))
+			default:
+				w.Write([]byte(`{"access_token":"tok","token_type":"bearer","expires_in":3600}`))
+			}
+		}
+	}))
+	defer server.Close()
+
+	cfg := DeviceConfig{DeviceAuthURL: server.URL + "/device", TokenURL: server.URL + "/token", ClientID: "cli"}
+	ctx := context.Background()
+
+	code, err := RequestDeviceCode(ctx, server.Client(), cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code.UserCode != "ABCD-EFGH" || code.VerificationURI != "https://example.com/device" || code.Interval != 5 {
+		t.Errorf("unexpected device code: %+v", code)
+	}
+
+	if _, err := PollToken(ctx, server.Client(), cfg, code.DeviceCode); !errors.Is(err, ErrAuthorizationPending) {
+		t.Errorf("expected ErrAuthorizationPending, got %v", err)
+	}
+	if _, err := PollToken(ctx, server.Client(), cfg, code.DeviceCode); !errors.Is(err, ErrSlowDown) {
+		t.Errorf("expected ErrSlowDown, got %v", err)
+	}
+	token, err := PollToken(ctx, server.Client(), cfg, code.DeviceCode)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "tok" || token.ExpiresIn != 3600 {
+		t.Errorf("unexpected token: %+v", token)
+	}
+}
+
+// TestPollTokenDenied tests that a terminal OAuth error is reported with its description.
+func TestPollTokenDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"access_denied","error_description":"The user denied the request"}`))
+	}))
+	defer server.Close()
+
+	cfg := DeviceConfig{TokenURL: server.URL, ClientID: "cli"}
+	_, err := PollToken(context.Background(), server.Client(), cfg, "dev")
+	if err == nil || err.Error() != "token request: access_denied: The user denied the request" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+// Package redact removes credentials from requests and responses before they are shared.
+// It is applied when copying to the clipboard or exporting, so pasted examples don't leak secrets.
+package redact
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/request"
+)
+
+// Placeholder replaces every redacted value.
+const Placeholder = "[REDACTED]"
+
+// DefaultHeaders are the header names whose values are always redacted.
+var DefaultHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// DefaultPatterns match common secrets in bodies, and the same names as query parameters,
+// e.g. in a URL repeated by an error message or listed as "api_key = value". Only the first
+// capture group is replaced.
+var DefaultPatterns = []string{
+	`(?i)"(?:access_token|refresh_token|id_token|client_secret|password|api_key|secret)"\s*:\s*"([^"]*)"`,
+	`(?i)\b(?:access_token|refresh_token|id_token|client_secret|password|api_key|apikey|secret)(?:=| = )([^&#\s"']+)`,
+}
+
+// headerLinePattern matches "Name: value" lines, as shown in the response headers view.
+var headerLinePattern = regexp.MustCompile(`(?m)^([A-Za-z0-9-]+):([ \t]*)(.*)$`)
+
+// Redactor redacts configured header values and pattern matches.
+// A nil Redactor performs no redaction.
+type Redactor struct {
+	headers  map[string]bool  // headers holds canonical names of headers to redact.
+	patterns []*regexp.Regexp // patterns are matched against free text such as bodies.
+}
+
+// New returns a Redactor for the default headers and patterns plus the given extras.
+// Patterns are Go regular expressions; if a pattern has a capture group only the first
+// group is replaced, otherwise the whole match is.
+func New(extraHeaders []string, extraPatterns []string) (*Redactor, error) {
+	r := &Redactor{headers: make(map[string]bool)}
+
+	for _, name := range append(append([]string(nil), DefaultHeaders...), extraHeaders...) {
+		r.headers[http.CanonicalHeaderKey(name)] = true
+	}
+
+	for _, pattern := range append(append([]string(nil), DefaultPatterns...), extraPatterns...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// IsSensitiveHeader reports whether the value of the named header is redacted.
+func (r *Redactor) IsSensitiveHeader(name string) bool {
+	if r == nil {
+		return false
+	}
+	return r.headers[http.CanonicalHeaderKey(name)]
+}
+
+// Text redacts free text: values of sensitive headers on "Name: value" lines,
+// then every pattern match.
+func (r *Redactor) Text(text string) string {
+	if r == nil {
+		return text
+	}
+
+	text = headerLinePattern.ReplaceAllStringFunc(text, func(line string) string {
+		parts := headerLinePattern.FindStringSubmatch(line)
+		if !r.IsSensitiveHeader(parts[1]) {
+			return line
+		}
+		return parts[1] + ":" + parts[2] + Placeholder
+	})
+
+	for _, re := range r.patterns {
+		text = replacePattern(re, text)
+	}
+	return text
+}
+
+// replacePattern replaces matches of re in text with Placeholder, limited to the
+// first capture group when re has one.
+func replacePattern(re *regexp.Regexp, text string) string {
+	if re.NumSubexp() == 0 {
+		return re.ReplaceAllString(text, Placeholder)
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, match := range re.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], match[3]
+		if start < 0 {
+			continue // Group did not participate in the match
+		}
+		result.WriteString(text[last:start])
+		result.WriteString(Placeholder)
+		last = end
+	}
+	result.WriteString(text[last:])
+	return result.String()
+}
+
+// Request returns a copy of req with sensitive headers, auth secrets and body matches redacted.
+func (r *Redactor) Request(req request.Request) request.Request {
+	if r == nil {
+		return req
+	}
+
+	if req.Headers != nil {
+		headers := make(map[string]string, len(req.Headers))
+		for name, value := range req.Headers {
+			if r.IsSensitiveHeader(name) {
+				value = Placeholder
+			}
+			headers[name] = value
+		}
+		req.Headers = headers
+	}
+
+	if req.Auth.Password != "" {
+		req.Auth.Password = Placeholder
+	}
+	if req.Auth.Token != "" {
+		req.Auth.Token = Placeholder
+	}
+
+	req.Body = r.Text(req.Body)
+	return req
+}
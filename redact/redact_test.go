@@ -0,0 +1,104 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/request"
+)
+
+// TestText tests redaction of header lines and body patterns.
+func TestText(t *testing.T) {
+	r, err := New([]string{"X-Session"}, []string{`sk_live_[A-Za-z0-9]+`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Default header",
+			input:    "Content-Type: text/plain\nauthorization: Bearer abc",
+			expected: "Content-Type: text/plain\nauthorization: " + Placeholder,
+		},
+		{
+			name:     "Extra header",
+			input:    "X-Session: 123",
+			expected: "X-Session: " + Placeholder,
+		},
+		{
+			name:     "Default body pattern only replaces the value",
+			input:    `{"user":"a","access_token": "xyz","n":1}`,
+			expected: `{"user":"a","access_token": "` + Placeholder + `","n":1}`,
+		},
+		{
+			name:     "Default query parameter pattern only replaces the value",
+			input:    `Get "https://api.example.com/items?page=2&api_key=k3y#top": timeout` + "\napikey = k3y  # URL",
+			expected: `Get "https://api.example.com/items?page=2&api_key=` + Placeholder + `#top": timeout` + "\napikey = " + Placeholder + "  # URL",
+		},
+		{
+			name:     "Extra pattern without group replaces the whole match",
+			input:    "key=sk_live_abc123 end",
+			expected: "key=" + Placeholder + " end",
+		},
+		{
+			name:     "Nothing to redact",
+			input:    "plain text",
+			expected: "plain text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Text(tt.input); got != tt.expected {
+				t.Errorf("Text() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestNewInvalidPattern tests that a malformed pattern is reported.
+func TestNewInvalidPattern(t *testing.T) {
+	if _, err := New(nil, []string{"("}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+// TestRequest tests that credentials are removed from a request without modifying the original.
+func TestRequest(t *testing.T) {
+	r, err := New(nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	original := request.Request{
+		URL:     "https://example.com",
+		Headers: map[string]string{"Authorization": "Bearer abc", "Accept": "*/*"},
+		Auth:    request.Auth{Type: "Basic", Username: "user", Password: "secret"},
+		Body:    `{"password":"hunter2"}`,
+	}
+
+	redacted := r.Request(original)
+	if redacted.Headers["Authorization"] != Placeholder || redacted.Headers["Accept"] != "*/*" {
+		t.Errorf("unexpected headers: %v", redacted.Headers)
+	}
+	if redacted.Auth.Username != "user" || redacted.Auth.Password != Placeholder {
+		t.Errorf("unexpected auth: %+v", redacted.Auth)
+	}
+	if redacted.Body != `{"password":"`+Placeholder+`"}` {
+		t.Errorf("unexpected body: %s", redacted.Body)
+	}
+	if original.Headers["Authorization"] != "Bearer abc" {
+		t.Error("original request was modified")
+	}
+}
+
+// TestNilRedactor tests that a nil Redactor leaves everything unchanged.
+func TestNilRedactor(t *testing.T) {
+	var r *Redactor
+	if got := r.Text("Authorization: x"); got != "Authorization: x" {
+		t.Errorf("expected no redaction, got %q", got)
+	}
+}
@@ -0,0 +1,126 @@
+// Package request defines the portable representation of an HTTP request built in LazyPost.
+// A Request captures everything entered in the form (method, URL, params, headers, auth
+// and body) so it can be shared as a file or a single string and loaded back later.
+package request
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// sharePrefix marks an encoded request string, so it can be recognised when pasted.
+const sharePrefix = "lazypost:"
+
+// Request is a snapshot of a request as entered in the UI.
+type Request struct {
+	Method          string            `json:"method"`                      // Method is the HTTP method, e.g. "GET".
+	URL             string            `json:"url"`                         // URL is the request URL without the Params query parameters.
+	Params          map[string]string `json:"params,omitempty"`            // Params are query parameters appended to URL when sending.
+	Headers         map[string]string `json:"headers,omitempty"`           // Headers are the request headers entered in the Headers tab.
+	Auth            Auth              `json:"auth"`                        // Auth holds the authentication settings.
+	Body            string            `json:"body,omitempty"`              // Body is the request body text.
+	LatencyBudgetMS int               `json:"latency_budget_ms,omitempty"` // LatencyBudgetMS flags responses slower than this many milliseconds, 0 for none.
+	BypassProxy     bool              `json:"bypass_proxy,omitempty"`      // BypassProxy sends the request directly, without the configured proxy.
+	DataBinary      bool              `json:"data_binary,omitempty"`       // DataBinary sends Body byte-for-byte as entered, like curl --data-binary.
+	Environment     string            `json:"environment,omitempty"`       // Environment pins the request to this environment instead of the selected one.
+	RawEncoding     bool              `json:"raw_encoding,omitempty"`      // RawEncoding requests identity encoding and shows the response body without decompression.
+}
+
+// Auth holds the authentication settings of a Request.
+// Only the fields relevant to Type are populated.
+type Auth struct {
+	Type     string `json:"type"`               // Type is the auth type as shown in the Auth tab, e.g. "None" or "Basic".
+	Username string `json:"username,omitempty"` // Username for Basic auth.
+	Password string `json:"password,omitempty"` // Password for Basic auth.
+	Token    string `json:"token,omitempty"`    // Token for Bearer auth.
+}
+
+// Encode returns the request as a single shareable string ("lazypost:" followed by
+// URL-safe base64 of its JSON form), suitable for pasting into chat or tickets.
+func (r Request) Encode() (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return sharePrefix + base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode parses a request from either a string produced by Encode or plain request JSON.
+// Surrounding whitespace is ignored.
+func Decode(s string) (Request, error) {
+	var r Request
+	s = strings.TrimSpace(s)
+
+	data := []byte(s)
+	if encoded, ok := strings.CutPrefix(s, sharePrefix); ok {
+		decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+		if err != nil {
+			return r, fmt.Errorf("invalid shared request: %w", err)
+		}
+		data = decoded
+	} else if !strings.HasPrefix(s, "{") {
+		return r, errors.New("not a shared request or request JSON")
+	}
+
+	if err := json.Unmarshal(data, &r); err != nil {
+		return r, fmt.Errorf("invalid request JSON: %w", err)
+	}
+	if r.URL == "" {
+		return r, errors.New("request has no URL")
+	}
+	return r, nil
+}
+
+// SaveFile writes the request to path as indented JSON.
+// The file may contain credentials, so it is only readable by the owner.
+func SaveFile(path string, r Request) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// LoadFile reads a request from a file containing request JSON or an encoded request string.
+func LoadFile(path string) (Request, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Request{}, err
+	}
+	r, err := Decode(string(data))
+	if err != nil {
+		return r, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
+
+// ParseHeader parses a header given as "Name: value", as with curl -H.
+func ParseHeader(s string) (string, string, error) {
+	name, value, found := strings.Cut(s, ":")
+	name = strings.TrimSpace(name)
+	if !found || name == "" {
+		return "", "", fmt.Errorf("invalid header %q: expected \"Name: value\"", s)
+	}
+	return name, strings.TrimSpace(value), nil
+}
+
+// ReadBody returns the body given on the command line: the contents of a file for
+// "@path", as with curl --data-binary, or the text itself otherwise.
+func ReadBody(arg string) (string, error) {
+	path, isFile := strings.CutPrefix(arg, "@")
+	if !isFile {
+		return arg, nil
+	}
+	if path == "-" {
+		return "", errors.New("reading the body from stdin is not supported, since the terminal UI needs it")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading body: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,113 @@
+package request
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sampleRequest returns a request with every field populated.
+func sampleRequest() Request {
+	return Request{
+		Method:  "POST",
+		URL:     "https://api.example.com/items",
+		Params:  map[string]string{"page": "2"},
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Auth:    Auth{Type: "Basic", Username: "user", Password: "p@ss"},
+		Body:    "{\"name\": \"x\"}\n",
+	}
+}
+
+// TestEncodeDecode tests that a request survives a round trip through the share string.
+func TestEncodeDecode(t *testing.T) {
+	original := sampleRequest()
+
+	encoded, err := original.Encode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(encoded, sharePrefix) {
+		t.Errorf("expected encoded string to start with %q, got %q", sharePrefix, encoded)
+	}
+
+	decoded, err := Decode("  " + encoded + "\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", decoded, original)
+	}
+}
+
+// TestDecodeInvalid tests that malformed input is rejected.
+func TestDecodeInvalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"https://example.com",
+		"lazypost:!!!",
+		`{"method": "GET"}`, // No URL
+		`{"url": `,
+	}
+	for _, input := range inputs {
+		if _, err := Decode(input); err == nil {
+			t.Errorf("Decode(%q) expected an error", input)
+		}
+	}
+}
+
+// TestSaveLoadFile tests that a request survives a round trip through a file.
+func TestSaveLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "request.json")
+	original := sampleRequest()
+
+	if err := SaveFile(path, original); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	loaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, original) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", loaded, original)
+	}
+}
+
+// TestParseHeader tests parsing headers given on the command line.
+func TestParseHeader(t *testing.T) {
+	name, value, err := ParseHeader("X-Trace:  1 ")
+	if err != nil || name != "X-Trace" || value != "1" {
+		t.Errorf("ParseHeader = %q, %q, %v; want X-Trace, 1, nil", name, value, err)
+	}
+	for _, invalid := range []string{"X-Trace", ": 1", ""} {
+		if _, _, err := ParseHeader(invalid); err == nil {
+			t.Errorf("ParseHeader(%q) succeeded, want an error", invalid)
+		}
+	}
+}
+
+// TestReadBody tests that @path reads the body from a file and other values are used as is.
+func TestReadBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "body.json")
+	if err := os.WriteFile(path, []byte("{\"a\": 1}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		{arg: `{"a": 1}`, want: `{"a": 1}`},
+		{arg: "@" + path, want: "{\"a\": 1}\n"},
+		{arg: "@" + path + ".missing", wantErr: true},
+		{arg: "@-", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ReadBody(tt.arg)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ReadBody(%q) = %q, %v; want %q, error %v", tt.arg, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
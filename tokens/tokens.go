@@ -0,0 +1,145 @@
+// Package tokens decodes and describes authentication tokens for inspection.
+// Nothing is verified: signatures are reported, not checked.
+package tokens
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JWT is a decoded JSON Web Token.
+type JWT struct {
+	Header    map[string]any // Header is the decoded JOSE header.
+	Claims    map[string]any // Claims is the decoded payload.
+	Signature []byte         // Signature is the raw signature, empty for unsecured tokens.
+}
+
+// DecodeJWT decodes a compact-serialized JWT without verifying its signature.
+// A "Bearer " prefix is ignored.
+func DecodeJWT(token string) (JWT, error) {
+	var decoded JWT
+
+	token = strings.TrimSpace(token)
+	token = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(token, "Bearer "), "bearer "))
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return decoded, fmt.Errorf("a JWT has 3 dot-separated parts, got %d", len(parts))
+	}
+
+	if err := decodeSegment(parts[0], &decoded.Header); err != nil {
+		return decoded, fmt.Errorf("header: %w", err)
+	}
+	if err := decodeSegment(parts[1], &decoded.Claims); err != nil {
+		return decoded, fmt.Errorf("claims: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[2], "="))
+	if err != nil {
+		return decoded, fmt.Errorf("signature: %w", err)
+	}
+	decoded.Signature = signature
+	return decoded, nil
+}
+
+// decodeSegment decodes a base64url JSON segment into v.
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Algorithm returns the signature algorithm named in the header, e.g. "RS256".
+func (t JWT) Algorithm() string {
+	alg, _ := t.Header["alg"].(string)
+	return alg
+}
+
+// Time returns a NumericDate claim such as "exp", "iat" or "nbf" as a time.
+func (t JWT) Time(claim string) (time.Time, bool) {
+	seconds, ok := t.Claims[claim].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+// ExpiryStatus describes the token's expiry relative to now, e.g. "expires in 4m10s"
+// or "expired 2h0m0s ago". It is empty when the token has no exp claim.
+func (t JWT) ExpiryStatus(now time.Time) string {
+	exp, ok := t.Time("exp")
+	if !ok {
+		return ""
+	}
+	remaining := exp.Sub(now).Truncate(time.Second)
+	if remaining > 0 {
+		return "expires in " + remaining.String()
+	}
+	return "expired " + (-remaining).String() + " ago"
+}
+
+// knownPrefixes maps prefixes of well-known opaque token formats to their issuer.
+var knownPrefixes = []struct {
+	prefix string
+	kind   string
+}{
+	{"github_pat_", "GitHub fine-grained personal access token"},
+	{"ghp_", "GitHub personal access token"},
+	{"gho_", "GitHub OAuth token"},
+	{"ghs_", "GitHub app installation token"},
+	{"glpat-", "GitLab personal access token"},
+	{"xoxb-", "Slack bot token"},
+	{"xoxp-", "Slack user token"},
+	{"sk_live_", "Stripe live secret key"},
+	{"sk_test_", "Stripe test secret key"},
+	{"AKIA", "AWS access key ID"},
+	{"ya29.", "Google OAuth access token"},
+}
+
+// DescribeOpaque returns facts about a token that is not a JWT: its length,
+// its apparent encoding and, for well-known formats, its issuer.
+func DescribeOpaque(token string) []string {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil
+	}
+
+	facts := []string{fmt.Sprintf("Length: %d characters", len(token))}
+
+	for _, known := range knownPrefixes {
+		if strings.HasPrefix(token, known.prefix) {
+			facts = append(facts, "Looks like: "+known.kind)
+			break
+		}
+	}
+
+	switch {
+	case len(token)%2 == 0 && isHex(token):
+		facts = append(facts, fmt.Sprintf("Encoding: hex (%d bytes)", len(token)/2))
+	case isBase64(token):
+		facts = append(facts, "Encoding: base64")
+	}
+	return facts
+}
+
+// isHex reports whether s consists only of hexadecimal digits.
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// isBase64 reports whether s decodes as standard or URL-safe base64, with or without padding.
+func isBase64(s string) bool {
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if _, err := encoding.DecodeString(s); err == nil {
+			return true
+		}
+	}
+	return false
+}
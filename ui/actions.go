@@ -1,12 +1,18 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/RAshkettle/LazyPost/vault"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -14,8 +20,18 @@ import (
 // It validates the URL, shows the loading spinner, and executes the request asynchronously.
 // Returns a tea.Cmd if any needs to be executed.
 func (a *App) handleSubmit() tea.Cmd {
+	if a.config.ReadOnly {
+		a.toast.Show(readOnlyMessage)
+		return nil
+	}
+
+	// Placeholders that resolve to nothing would be sent literally
+	if !a.checkVariables() || !a.checkFields() {
+		return nil
+	}
+
 	// Validate URL
-	rawURL := a.urlInput.GetText()
+	rawURL := a.requestURL()
 	isValid := validateURL(rawURL)
 	if !isValid {
 		// Show a toast notification for invalid URL
@@ -28,6 +44,14 @@ func (a *App) handleSubmit() tea.Cmd {
 		return nil
 	}
 
+	// A new request replaces any pending Retry-After countdown
+	a.cancelRetryCountdown()
+	a.keepQueryFocus = false
+
+	// Sent requests can be switched back to later
+	sent := a.snapshotRequest()
+	a.rememberRequest(sent)
+
 	// Prepare for request - don't change focus yet
 	a.methodSelector.SetActive(false)
 	a.urlInput.SetActive(false)
@@ -36,94 +60,309 @@ func (a *App) handleSubmit() tea.Cmd {
 	// Show the loading spinner directly over the URL input
 	spinnerCmd := a.spinner.Show("Sending request...")
 
+	prepared, err := a.prepareRequest(rawURL)
+	if err != nil {
+		// This error would typically be from parsing the rawURL, which should be caught by validateURL
+		// but as a safeguard:
+		a.toast.Show(fmt.Sprintf("Error building URL: %v", err))
+		a.spinner.Hide()           // Hide spinner as we are not proceeding
+		a.urlInput.SetActive(true) // Allow user to correct URL
+		return nil
+	}
+	a.rememberRequestID(sent, prepared.requestID)
+	record := &sentRequest{prepared: prepared, started: time.Now()}
+	if e := a.requestEnvironment(); e != nil {
+		record.environment = e.Name
+	}
+	a.recordStep(prepared)
+	if prepared.dataBinary && a.bodyEditedLossily() {
+		a.toast.Show("Body was edited after loading: tabs, carriage returns and control bytes are sent as the editor shows them")
+	}
+
+	// Execute the HTTP request as a background job, which Esc can cancel
+	return tea.Batch(
+		spinnerCmd,
+		a.jobs.start(job{name: requestJobName, run: prepared.send, sent: record}),
+	)
+}
+
+// send resolves and sends the prepared request. It is run as a background job.
+func (p preparedRequest) send(ctx context.Context, progress func(string)) (tea.Msg, error) {
+	if requestHasVaultPlaceholders(p.rawURL, p.params, p.headers) || (!p.dataBinary && vault.HasPlaceholders(p.body)) {
+		progress("Resolving Vault secrets...")
+	}
+	finalURL, headers, body, err := p.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	progress("Sending request...")
+	resp, err := p.engine.send(ctx, p.method, finalURL, headers, body, p.route)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the response data
+	wait, hasRetry := retryAfter(resp.StatusCode, resp.Header, time.Now())
+	return RequestCompleteMsg{
+		Headers:     formatResponseHeaders(resp, p),
+		Body:        resp.Body,
+		ContentType: resp.Header.Get("Content-Type"),
+		RetryAfter:  wait,
+		HasRetry:    hasRetry,
+		StatusCode:  resp.StatusCode,
+	}, nil
+}
+
+// preparedRequest is a request captured from the form, ready to be sent from a command.
+type preparedRequest struct {
+	method       string            // HTTP method
+	rawURL       string            // URL as entered, possibly containing Vault placeholders
+	finalURL     string            // URL with query parameters applied
+	params       map[string]string // Query parameters
+	headers      map[string]string // Headers from host rules, the headers table and auth, in increasing precedence
+	sources      map[string]string // Where each header came from, for the request preview
+	firedRules   []string          // Host patterns of the host rules that contributed headers
+	vaultAddress string            // Vault server used for placeholders, empty to use VAULT_ADDR
+	budget       time.Duration     // Latency budget the response time is checked against, 0 for none
+	lint         variableLint      // Unresolved and unused environment variables
+	route        proxyRoute        // Whether the request goes through a proxy, and why
+	body         string            // Body to send, empty for none
+	dataBinary   bool              // Whether body is sent exactly as entered, without placeholder substitution
+	rawEncoding  bool              // Whether the response body is shown as received, without decompression
+	secrets      map[string]string // Values of the secret environment variables the request references, by name
+
+	requestIDHeader string // Header carrying the correlation ID, empty when none is sent
+	requestID       string // Correlation ID the request is sent with, to match against the response
+
+	engine requestEngine // Engine the request is sent with
+}
+
+// prepareRequest captures the method, URL, parameters and headers currently entered in the form.
+func (a *App) prepareRequest(rawURL string) (preparedRequest, error) {
 	// Get selected HTTP method
 	method := a.methodSelector.GetSelectedMethod()
 
 	// Get parameters from ParamsContainer via QueryTab
 	// The GetQueryTab() method is now available on TabsContainer
 	queryParams := a.tabContainer.GetQueryTab().ParamsInput.GetParams()
+	queryParams = expandParams(a.requestEnvironment(), queryParams)
 	finalURL, err := buildURLWithParams(rawURL, queryParams)
 	if err != nil {
-		// This error would typically be from parsing the rawURL, which should be caught by validateURL
-		// but as a safeguard:
-		a.toast.Show(fmt.Sprintf("Error building URL: %v", err))
-		a.spinner.Hide()           // Hide spinner as we are not proceeding
-		a.urlInput.SetActive(true) // Allow user to correct URL
-		return nil
+		return preparedRequest{}, err
 	}
 
+	// Start from the defaults of any matching host rules, so the UI can override them
+	headerSources := make(map[string]string)
+	headers, firedRules := hostRuleHeaders(a.config.HostRules, finalURL, headerSources)
+
 	// Get headers from HeadersInputContainer via QueryTab
-	headers := a.tabContainer.GetQueryTab().HeadersInput.GetHeaders()
+	mergeHeaders(headers, a.tabContainer.GetQueryTab().HeadersInput.GetHeaders(), headerSources, "Headers tab")
 
 	// Get auth headers from AuthContainer via QueryTab
 	authHeaders := a.tabContainer.GetQueryTab().AuthInput.GetAuthHeaders()
-	for key, value := range authHeaders {
-		headers[key] = value // Add or overwrite headers with auth headers
+	authSource := "auth (" + a.tabContainer.GetQueryTab().AuthInput.GetAuthType() + ")"
+	mergeHeaders(headers, authHeaders, headerSources, authSource) // Add or overwrite headers with auth headers
+	headers = expandValues(a.requestEnvironment(), headers)
+	if a.rawEncoding {
+		addIdentityEncoding(headers, headerSources)
 	}
+	requestID := addRequestID(a.config.RequestIDHeader, headers, headerSources)
 
-	// Return a command that will execute the HTTP request asynchronously
-	return tea.Batch(
-		spinnerCmd,
-		func() tea.Msg {
-			// Create HTTP client
-			client := &http.Client{}
-
-			// Create request with the selected method and potentially modified URL
-			req, err := http.NewRequest(method, finalURL, nil)
-			if err != nil {
-				return RequestCompleteMsg{
-					Error: err,
-				}
-			}
+	route, err := a.routeFor(finalURL)
+	if err != nil {
+		return preparedRequest{}, err
+	}
 
-			// Add headers to the request
-			for key, value := range headers {
-				req.Header.Set(key, value)
-			}
+	body := requestBody(a.bodyText(), a.requestEnvironment(), a.dataBinary)
 
-			// Execute the HTTP request
-			resp, err := client.Do(req)
-			if err != nil {
-				return RequestCompleteMsg{
-					Error: err,
-				}
-			}
-			defer func() {
-				err := resp.Body.Close()
-				if err != nil {
-					fmt.Println("failure to close body")
-				}
-			}()
-
-			// Process response headers
-			var headersContent strings.Builder
-
-			// Add yellow and bold formatting for the "Status:" label
-			headersContent.WriteString(fmt.Sprintf("\033[1;33mStatus:\033[0m %s\n\n", resp.Status))
-
-			// Format each header with yellow and bold for the header name and colon
-			for key, values := range resp.Header {
-				for _, value := range values {
-					headersContent.WriteString(fmt.Sprintf("\033[1;33m%s:\033[0m %s\n", key, value))
-				}
-			}
+	return preparedRequest{
+		method:       method,
+		rawURL:       rawURL,
+		finalURL:     finalURL,
+		params:       queryParams,
+		headers:      headers,
+		sources:      headerSources,
+		firedRules:   firedRules,
+		vaultAddress: a.config.Vault.Address,
+		budget:       latencyBudget(a.config, finalURL, a.latencyBudget),
+		lint:         lintVariables(a.requestEnvironment(), a.requestTemplates()),
+		route:        route,
+		body:         body,
+		dataBinary:   a.dataBinary,
+		rawEncoding:  a.rawEncoding,
+		secrets:      secretValues(a.requestEnvironment(), a.requestTemplates()),
 
-			// Process response body
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return RequestCompleteMsg{
-					Error:   err,
-					Headers: headersContent.String(),
-				}
-			}
+		requestIDHeader: http.CanonicalHeaderKey(a.config.RequestIDHeader),
+		requestID:       requestID,
 
-			// Return the response data
-			return RequestCompleteMsg{
-				Headers: headersContent.String(),
-				Body:    string(body),
-			}
+		engine: a.engine,
+	}, nil
+}
+
+// resolve returns the URL to send to, the headers and the body, resolving Vault placeholders
+// in the URL, parameters, headers and, unless it is sent as entered, the body first. p is
+// not changed. It contacts Vault, so it must be called from a command.
+func (p preparedRequest) resolve() (string, map[string]string, string, error) {
+	// Resolve Vault secrets at send time so they are never stored in the form
+	finalURL, headers, body := p.finalURL, p.headers, p.body
+	var err error
+	if requestHasVaultPlaceholders(p.rawURL, p.params, p.headers) {
+		if finalURL, headers, err = resolveVaultPlaceholders(p.vaultAddress, p.rawURL, p.params, p.headers); err != nil {
+			return "", nil, "", err
+		}
+	}
+	if !p.dataBinary && vault.HasPlaceholders(body) {
+		if body, err = vault.NewClient(p.vaultAddress).Resolve(body); err != nil {
+			return "", nil, "", err
+		}
+	}
+	return finalURL, headers, body, nil
+}
+
+// response holds the parts of an HTTP response that LazyPost displays.
+type response struct {
+	Status     string            // Status line, e.g. "200 OK"
+	StatusCode int               // Numeric status code, e.g. 200
+	Header     http.Header       // Response headers
+	Trailer    http.Header       // Trailers received after the body, nil if none were declared
+	Interim    []interimResponse // Informational (1xx) responses received before this one
+	Body       []byte            // Full response body
+	Elapsed    time.Duration     // Time from sending the request until the body was read
+
+	Decompressed bool // Whether the transport transparently decoded a gzip-encoded body
+}
+
+// sendRequest sends a request with the given method, URL, headers and body along route and
+// reads the whole response. An empty body sends none. Fields named by a Trailer header are
+// sent as trailers after a chunked body (see splitTrailers). A Host header naming another
+// host addresses the request to it while connecting to the URL's host (see hostOverride).
+// If the body cannot be read, the returned response still holds the status and headers.
+// Canceling ctx aborts the request. Errors from sending the request are returned as a
+// *requestError, classified by their cause.
+func sendRequest(ctx context.Context, method, requestURL string, headers map[string]string, body string, route proxyRoute) (response, error) {
+	// Create HTTP client; its transport is only used for this request, so its kept-alive
+	// connections are closed once the response has been read
+	transport := route.transport()
+	defer transport.CloseIdleConnections()
+	headers, override, err := splitHostOverride(requestURL, headers)
+	if err != nil {
+		return response{}, err
+	}
+	if override != nil {
+		if requestURL, err = override.rewriteURL(requestURL); err != nil {
+			return response{}, err
+		}
+		override.apply(transport)
+	}
+	client := &http.Client{Transport: transport, CheckRedirect: checkRedirect}
+
+	// The body is sent as-is; strings.Reader also lets redirects resend it
+	headers, trailers := splitTrailers(headers)
+	var bodyReader io.Reader
+	if body != "" || trailers != nil {
+		bodyReader = strings.NewReader(body)
+	}
+
+	// Create request with the selected method and potentially modified URL
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, bodyReader)
+	if err != nil {
+		return response{}, err
+	}
+
+	// Add headers to the request
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if override != nil {
+		req.Host = override.name
+	}
+	if trailers != nil {
+		req.Trailer = trailers
+		req.ContentLength = -1 // Trailers follow a chunked body
+	}
+
+	// Execute the HTTP request, keeping informational responses such as 103 Early Hints,
+	// which the client would otherwise discard
+	start := time.Now()
+	var interim []interimResponse
+	req = req.WithContext(httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			interim = append(interim, interimResponse{StatusCode: code, Header: http.Header(header).Clone(), Elapsed: time.Since(start)})
+			return nil
 		},
-	)
+	}))
+	resp, err := client.Do(req)
+	if err != nil {
+		return response{}, classifyError(err)
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			fmt.Println("failure to close body")
+		}
+	}()
+
+	result := response{Status: resp.Status, StatusCode: resp.StatusCode, Header: resp.Header, Interim: interim, Decompressed: resp.Uncompressed}
+
+	// Process response body
+	result.Body, err = io.ReadAll(resp.Body)
+	result.Elapsed = time.Since(start)
+	result.Trailer = resp.Trailer // Filled in once the body has been read
+	return result, err
+}
+
+// formatResponseHeaders renders the status line, the response time, the host rules that
+// fired and the response headers for the Headers result view.
+func formatResponseHeaders(resp response, prepared preparedRequest) string {
+	if resp.Status == "" {
+		return "" // No response was received
+	}
+
+	var headersContent strings.Builder
+
+	// Add yellow and bold formatting for the "Status:" label
+	headersContent.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render("Status:"), resp.Status))
+	headersContent.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render("Time:"), formatLatency(resp.Elapsed, prepared.budget)))
+	headersContent.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render("Route:"), prepared.route))
+
+	// Show which host rules contributed headers to the request
+	if len(prepared.firedRules) > 0 {
+		headersContent.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render("Host rules applied:"), strings.Join(prepared.firedRules, ", ")))
+	}
+
+	if prepared.rawEncoding {
+		headersContent.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render("Encoding:"), responseEncoding(resp.Header.Get("Content-Encoding"))))
+	}
+
+	// The correlation ID links this response to server logs
+	headersContent.WriteString(formatRequestID(resp, prepared))
+
+	// Hashes of the body, and whether it matches digests sent by the server
+	headersContent.WriteString(formatIntegrity(resp))
+
+	// Defined variables the request never referenced may point at a typo
+	if len(prepared.lint.unused) > 0 {
+		headersContent.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render("Unused variables:"), strings.Join(prepared.lint.unused, ", ")))
+	}
+
+	// 1xx responses that arrived before the final one, e.g. 103 Early Hints
+	headersContent.WriteString(formatInterimResponses(resp.Interim))
+	headersContent.WriteString("\n")
+
+	// Format each header with yellow and bold for the header name and colon
+	for key, values := range resp.Header {
+		for _, value := range values {
+			if prepared.requestID != "" && key == prepared.requestIDHeader && strings.TrimSpace(value) == prepared.requestID {
+				value = styles.SelectedItemStyle.Render(value) + " (matches the request)"
+			}
+			headersContent.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render(key+":"), value))
+		}
+	}
+
+	// Trailers arrive after the body, e.g. gRPC-Web status or checksums
+	headersContent.WriteString(formatTrailers(resp.Trailer))
+	return headersContent.String()
 }
 
 // buildURLWithParams takes a raw URL string and a map of query parameters,
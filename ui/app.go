@@ -3,9 +3,13 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
+	"time"
 
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/env"
+	"github.com/RAshkettle/LazyPost/redact"
+	"github.com/RAshkettle/LazyPost/request"
 	"github.com/RAshkettle/LazyPost/ui/components"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,6 +18,8 @@ import (
 
 // App represents the main application model.
 // It embeds all UI components and manages the application state and logic.
+// App is always used through a pointer: every method, including those of tea.Model,
+// mutates the same instance, so no state is lost to copies between messages.
 type App struct {
 	methodSelector components.MethodSelector // Component for selecting HTTP method.
 	urlInput       components.URLInput       // Component for URL input.
@@ -26,21 +32,75 @@ type App struct {
 	urlInputWidth  int                       // Cached width of the URL input, used for spinner positioning.
 	urlInputX      int                       // Cached X coordinate of the URL input, used for spinner positioning.
 	keymap         KeyMap                    // Defines keybindings for the application.
+	config         config.Config             // User configuration loaded at startup.
+	redactor       *redact.Redactor          // Redacts credentials from copied and exported text, nil when disabled.
+	picker         components.Picker         // Modal list used to choose a value, such as a discovered service.
+	pickerMode     pickerMode                // What the picker's choice is used for.
+	prompt         components.Prompt         // Modal text input used to ask for a value, such as a compare URL.
+	promptMode     promptMode                // What the prompt's value is used for.
+	compareBaseURL string                    // Base URL last used for a response comparison.
+	tokenInspector components.TokenInspector // Modal view decoding JWTs and opaque tokens.
+	scratchpad     components.Scratchpad     // Modal view encoding and decoding text, e.g. base64 or URL encoding.
+	timeTool       components.TimeTool       // Modal view converting time expressions into timestamps to insert.
+	latencyBudget  time.Duration             // Latency budget set for the current request, 0 to use the configured one.
+	retryAt        time.Time                 // End of the Retry-After window of the last response, zero when none.
+	retryID        int                       // Identifies the current Retry-After countdown.
+	autoResend     bool                      // Whether to resend automatically when the Retry-After window elapses.
+	bypassProxy    bool                      // Whether the current request is sent directly, without the proxy.
+	dataBinary     bool                      // Whether the body is sent byte-for-byte as entered, like curl --data-binary.
+	rawEncoding    bool                      // Whether identity encoding is requested and responses are shown without decompression.
+	textViewer     components.TextViewer     // Modal scrollable text, such as the raw request preview.
+	recentRequests []request.Request         // Recently sent, imported or switched-from requests, most recent first.
+
+	environments      env.Store                    // Environments whose variables are substituted into {{name}} placeholders.
+	environmentsPath  string                       // File the environment editor saves to, empty when there is none.
+	environmentEditor components.EnvironmentEditor // Full-screen editor for environments and variables.
+	jobs              jobRunner                    // Background jobs such as requests in flight.
+	pinnedEnvironment string                       // Environment the current request is always sent with, empty to use the selected one.
+	keepQueryFocus    bool                         // Whether the next response badges the Result tab instead of taking focus from the Query tab.
+	recentRequestIDs  map[string]string            // Correlation ID of the last send of each recent request, by request key.
+	secretsShown      bool                         // Whether passwords and tokens in the Auth panel are shown in plain text.
+	privacyMode       bool                         // Whether secrets are masked everywhere in the UI, e.g. while screen sharing.
+	privacyRedactor   *redact.Redactor             // Masks secrets in privacy mode, even when redaction of copies is disabled.
+	engine            requestEngine                // Sends requests; replaced in tests.
+	lastFailure       *errorReport                 // Context of the last failed request, for the error report.
+	bodyFile          string                       // File the body was read from, empty if it was not.
+	loadedBody        string                       // Body exactly as last loaded, which the editor may show with tabs, carriage returns and control bytes changed.
+	loadedBodyShown   string                       // The editor's content right after the body was loaded, to tell whether it has been edited since.
+	watching          bool                         // Whether the request is resent when a watched file changes.
+	watchID           int                          // Identifies the current watch, so ticks of an earlier one are ignored.
+	watch             fileWatch                    // Files the request depends on and their last seen versions.
+	recording         *sessionRecording            // Requests sent since recording started, nil when not recording.
 }
 
-// NewApp initializes and returns a new App model.
+// NewApp initializes and returns a pointer to a new App model.
 // It sets up all the necessary UI components, loads the banner, and prepares the initial state.
-func NewApp() App {
+// cfg holds the user configuration, already merged with any command line flags.
+func NewApp(cfg config.Config) *App {
 	methodSelector := components.NewMethodSelector()
 	urlInput := components.NewURLInput()
 	submitButton := components.NewButton("Submit")
 	tabContainer := components.NewTabsContainer()
 	toast := components.NewToast()
 	spinner := components.NewSpinner()
+	picker := components.NewPicker()
+	prompt := components.NewPrompt()
+	tokenInspector := components.NewTokenInspector()
+	scratchpad := components.NewScratchpad()
+	timeTool := components.NewTimeTool()
+	textViewer := components.NewTextViewer()
+	environmentEditor := components.NewEnvironmentEditor()
+
+	// Patterns were validated when the config was loaded
+	var redactor *redact.Redactor
+	if !cfg.Redaction.Disabled {
+		redactor, _ = redact.New(cfg.Redaction.Headers, cfg.Redaction.Patterns)
+		tabContainer.ResultTab.SetCopyFilter(redactor.Text)
+		textViewer.SetCopyFilter(redactor.Text)
+	}
+	privacyRedactor, _ := redact.New(cfg.Redaction.Headers, cfg.Redaction.Patterns)
 
-
-
-	return App{
+	app := &App{
 		methodSelector: methodSelector,
 		urlInput:       urlInput,
 		submitButton:   submitButton,
@@ -50,13 +110,27 @@ func NewApp() App {
 		width:          0,
 		height:         0,
 		keymap:         DefaultKeyMap,
-
+		config:         cfg,
+		redactor:       redactor,
+		picker:         picker,
+		prompt:         prompt,
+		tokenInspector: tokenInspector,
+		scratchpad:     scratchpad,
+		timeTool:       timeTool,
+		textViewer:     textViewer,
+
+		environmentEditor: environmentEditor,
+		privacyMode:       cfg.PrivacyMode,
+		privacyRedactor:   privacyRedactor,
+		engine:            httpEngine{},
 	}
+	app.applySecretMasking()
+	return app
 }
 
 // Init is the first command that is run when the application starts.
 // It satisfies the tea.Model interface.
-func (a App) Init() tea.Cmd {
+func (a *App) Init() tea.Cmd {
 	return tea.Batch(
 		a.urlInput.TextInput.Focus(),
 	)
@@ -64,12 +138,47 @@ func (a App) Init() tea.Cmd {
 
 // Update handles incoming messages and updates the App model accordingly.
 // It is a central part of the Bubble Tea event loop and satisfies the tea.Model interface.
-func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case RequestCompleteMsg:
-		a.handleRequestCompleteMsg(msg)
+		return a, a.handleRequestCompleteMsg(msg)
+
+	case jobProgressMsg:
+		return a, a.handleJobProgressMsg(msg)
+
+	case jobDoneMsg:
+		return a, a.handleJobDoneMsg(msg)
+
+	case retryTickMsg:
+		return a, a.handleRetryTick(msg)
+
+	case watchTickMsg:
+		return a, a.handleWatchTick(msg)
+
+	case components.ShowToastMsg:
+		// A component asked for user feedback (e.g. a saved file or a clipboard error)
+		a.toast.Show(msg.Message)
+		return a, nil
+
+	case components.RunJobMsg:
+		// A component asked for work to run as a background job (e.g. a token fetch)
+		return a, a.jobs.start(job{name: msg.Name, run: msg.Run})
+
+	case components.OAuth2FlowMsg:
+		// Device flow progress goes to the OAuth2 panel even when it isn't focused
+		return a, a.tabContainer.GetQueryTab().AuthInput.UpdateOAuth2Flow(msg)
+
+	case components.TokenInspectorTickMsg:
+		return a, a.tokenInspector.Tick(msg)
+
+	case CompareCompleteMsg:
+		a.handleCompareCompleteMsg(msg)
+		return a, nil
+
+	case ServicesDiscoveredMsg:
+		a.handleServicesDiscoveredMsg(msg)
 		return a, nil
 
 	case components.SpinnerTickMsg:
@@ -112,6 +221,67 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg, cmds []tea.Cmd) ([]tea.Cmd, bool,  te
 		return nil, true,  nil
 	}
 
+	// The environment editor captures all key presses, including Esc which would otherwise quit
+	if a.environmentEditor.Visible {
+		return nil, true, a.handleEnvironmentEditorKey(msg)
+	}
+
+	// The text viewer captures all key presses, including Esc which would otherwise quit
+	if a.textViewer.Visible {
+		return nil, true, a.textViewer.Update(msg)
+	}
+
+	// The token inspector captures all key presses, including Esc which would otherwise quit
+	if a.tokenInspector.Visible {
+		return nil, true, a.tokenInspector.Update(msg)
+	}
+
+	// The scratchpad captures all key presses, including Esc which would otherwise quit
+	if a.scratchpad.Visible {
+		return nil, true, a.scratchpad.Update(msg)
+	}
+
+	// The time tool captures all key presses, including Esc which would otherwise quit
+	if a.timeTool.Visible {
+		value, chosen, cmd := a.timeTool.Update(msg)
+		if chosen {
+			a.insertText(value)
+		}
+		return nil, true, cmd
+	}
+
+	// An open prompt captures all key presses, including Esc which would otherwise quit
+	if a.prompt.Visible {
+		value, submitted, cmd := a.prompt.Update(msg)
+		if submitted {
+			return nil, true, a.handlePromptSubmit(value)
+		}
+		return nil, true, cmd
+	}
+
+	// An open picker captures all key presses, including Esc which would otherwise quit
+	if a.picker.Visible {
+		// Repeating the switcher key cycles through the recent requests
+		if a.pickerMode == pickerRecent && key.Matches(msg, a.keymap.RecentRequests) {
+			a.handleRecentRequests()
+			return nil, true, nil
+		}
+		if item, chosen := a.picker.Update(msg); chosen {
+			a.handlePickerChoice(item)
+		}
+		return nil, true, nil
+	}
+
+	// An open input in the Body view captures all key presses, including Esc which would otherwise quit
+	if bodyTab := &a.tabContainer.GetResultTab().BodyTab; bodyTab.Editing() {
+		return nil, true, bodyTab.Update(msg)
+	}
+
+	// Read-only mode refuses anything that would send or change the request
+	if a.readOnlyRefuses(msg) {
+		return nil, true, nil
+	}
+
 	// Check for Alt key + rune combinations first if key.Matches fails for standard "alt+<key>"
 	// This is to handle terminals that send runes directly for Alt combinations.
 	if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
@@ -136,6 +306,16 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg, cmds []tea.Cmd) ([]tea.Cmd, bool,  te
 	}
 
 
+	// Esc cancels work in progress instead of quitting
+	if msg.String() == "esc" && a.jobs.cancelAll() {
+		return nil, true, nil
+	}
+
+	// Esc clears a line selection in the response body before quitting
+	if msg.String() == "esc" && a.tabContainer.GetResultTab().BodyTab.ClearSelection() {
+		return nil, true, nil
+	}
+
 	switch {
 	case key.Matches(msg, a.keymap.Quit):
 		return nil, true,  tea.Quit
@@ -165,17 +345,142 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg, cmds []tea.Cmd) ([]tea.Cmd, bool,  te
 		a.setFocus(focusResult)
 		return nil, true,  nil
 
+	case key.Matches(msg, a.keymap.ExportRequest):
+		// Save the request to a file and copy a shareable string
+		cmd := a.handleExportRequest()
+		return nil, true,  cmd
+
+	case key.Matches(msg, a.keymap.ImportRequest):
+		// Replace the form with a request from the clipboard
+		a.handleImportRequest()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.DiscoverServices):
+		// Scan for local services and offer them as URL targets
+		cmd := a.handleDiscoverServices()
+		return nil, true,  cmd
+
+	case key.Matches(msg, a.keymap.Compare):
+		// Send the request to a second base URL and diff the responses
+		cmd := a.handleCompare()
+		return nil, true,  cmd
+
+	case key.Matches(msg, a.keymap.InspectToken):
+		// Decode the token currently used for auth, or one pasted by the user
+		cmd := a.tokenInspector.Open(a.privacyPrefill(a.currentAuthToken()))
+		return nil, true,  cmd
+
+	case key.Matches(msg, a.keymap.RecentRequests):
+		// Open the most-recently-used request switcher
+		a.handleRecentRequests()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.EditEnvironments):
+		// Open the full-screen environment and variable editor
+		a.handleEditEnvironments()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.BypassProxy):
+		// Toggle sending the current request without the proxy
+		a.handleToggleProxyBypass()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.SelectLocale):
+		// Pick a locale to set Accept-Language and related headers
+		a.handleLocaleSelector()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.DataBinary):
+		// Toggle sending the body exactly as entered
+		a.handleToggleDataBinary()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.Scratchpad):
+		// Encode or decode the focused field's text, or text pasted by the user
+		text, _, _ := a.focusedText()
+		cmd := a.scratchpad.Open(a.privacyPrefill(text))
+		return nil, true,  cmd
+
+	case key.Matches(msg, a.keymap.TimeTool):
+		// Convert a time expression and insert it into the focused field
+		cmd := a.timeTool.Open()
+		return nil, true,  cmd
+
+	case key.Matches(msg, a.keymap.PinEnvironment):
+		// Choose an environment this request is always sent with
+		a.handlePinEnvironment()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.RawEncoding):
+		// Toggle requesting identity encoding and showing bodies as received
+		a.handleToggleRawEncoding()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.ShowSecrets):
+		// Show or hide passwords and tokens in the Auth panel
+		a.handleToggleSecrets()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.PrivacyMode):
+		// Mask secrets everywhere, e.g. while sharing the screen
+		a.handleTogglePrivacy()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.ErrorReport):
+		// Save the context of the last failed request for a bug report
+		cmd := a.handleExportErrorReport()
+		return nil, true,  cmd
+
+	case key.Matches(msg, a.keymap.WatchFiles):
+		// Resend the request whenever its body file or the environments change on disk
+		cmd := a.handleToggleWatch()
+		return nil, true,  cmd
+
+	case key.Matches(msg, a.keymap.StepUp):
+		// Increment the number or date at the cursor of the focused field
+		a.handleStep(1)
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.StepDown):
+		// Decrement the number or date at the cursor of the focused field
+		a.handleStep(-1)
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.RecordSession):
+		// Record the requests sent from now on, or stop and export them as a replay script
+		cmd := a.handleToggleRecording()
+		return nil, true,  cmd
+
+	case key.Matches(msg, a.keymap.PreviewRequest):
+		// Show the merged request with the source of each header and parameter
+		a.handlePreviewRequest()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.AutoResend):
+		// Toggle resending when a Retry-After window elapses
+		a.handleToggleAutoResend()
+		return nil, true,  nil
+
+	case key.Matches(msg, a.keymap.LatencyBudget):
+		// Ask for the latency budget of the current request
+		cmd := a.handleLatencyBudget()
+		return nil, true,  cmd
+
 	case key.Matches(msg, a.keymap.Next), key.Matches(msg, a.keymap.Prev):
 		// Tab and Shift+Tab only work in tab containers
 		if a.tabContainer.Active {
-			a.tabContainer.Update(msg)
-			return nil, true,  nil
+			cmd := a.tabContainer.Update(msg)
+			return nil, true,  cmd
 		}
 		// Otherwise, ignore tab/shift+tab
 		return nil, true,  nil
 
 	// Let the active component handle other key presses
 	default:
+		if !a.readOnlyAllowsKey(msg) {
+			return nil, true,  nil
+		}
+
 		// Special handling for arrow keys
 		switch msg.String() {
 		case "up", "down", "left", "right":
@@ -191,8 +496,8 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg, cmds []tea.Cmd) ([]tea.Cmd, bool,  te
 				return nil, true,  tea.Batch(cmds...)
 			} else if a.tabContainer.Active {
 				// Tab container might handle arrow keys
-				a.tabContainer.Update(msg)
-				return nil, true,  nil
+				cmd := a.tabContainer.Update(msg)
+				return nil, true,  cmd
 			}
 		}
 
@@ -215,7 +520,13 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg, cmds []tea.Cmd) ([]tea.Cmd, bool,  te
 				return nil, true,  cmd
 			}
 		} else if a.tabContainer.Active {
-			a.tabContainer.Update(msg)
+			// Typing in the Query tab while a request runs keeps the focus there
+			if a.tabContainer.ActiveTab == 0 && a.jobs.running() {
+				a.keepQueryFocus = true
+			}
+			if cmd := a.tabContainer.Update(msg); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 
 	}
@@ -254,11 +565,12 @@ func (a *App) setFocus(target focusTarget) {
 	case focusResult:
 		a.tabContainer.SwitchToTab(1) // Result tab is index 1
 		a.tabContainer.SetActive(true)
-	// focusSubmit is handled by handleSubmit directly
+	case focusSubmit:
+		a.submitButton.SetActive(true) // Enter then sends the request
 	}
 }
 
-func(a *App) handleWindowSizeMsg(msg tea.WindowSizeMsg) {
+func (a *App) handleWindowSizeMsg(msg tea.WindowSizeMsg) {
 	a.width = msg.Width
 	a.height = msg.Height
 
@@ -295,6 +607,13 @@ func(a *App) handleWindowSizeMsg(msg tea.WindowSizeMsg) {
 	toastWidth := int(float64(availableWidth) * 0.5) // Half the available width
 	a.toast.SetWidth(toastWidth)
 	a.toast.SetHeight(5) // Fixed height
+	a.picker.SetWidth(toastWidth)
+	a.prompt.SetWidth(toastWidth)
+	a.tokenInspector.SetWidth(int(float64(availableWidth) * 0.8))
+	a.scratchpad.SetWidth(int(float64(availableWidth) * 0.8))
+	a.timeTool.SetWidth(toastWidth)
+	a.textViewer.SetSize(int(float64(availableWidth)*0.8), int(float64(a.height)*0.8))
+	a.environmentEditor.SetSize(availableWidth, int(float64(a.height)*0.9))
 
 	// Set spinner dimensions to match the URL input
 	a.spinner.SetWidth(urlBoxWidth)
@@ -302,34 +621,43 @@ func(a *App) handleWindowSizeMsg(msg tea.WindowSizeMsg) {
 	a.spinner.SetPosition(a.urlInputX, 3)
 }
 
-func(a *App) handleRequestCompleteMsg(msg RequestCompleteMsg) {
+func (a *App) handleRequestCompleteMsg(msg RequestCompleteMsg) tea.Cmd {
 	a.spinner.Hide()
 
-	if msg.Error != nil {
-		// Show error toast and allow user to try again
-		a.toast.Show(fmt.Sprintf("Error: %s", msg.Error.Error()))
-		// Move focus back to URL input
-		a.methodSelector.SetActive(false)
-		a.urlInput.SetActive(true)
-		a.submitButton.SetActive(false)
-		a.tabContainer.SetActive(false)
-	}
-
 	// Update the result tabs with response data
 	resultTab := a.tabContainer.GetResultTab()
 	resultTab.SetHeadersContent(msg.Headers) // Headers tab
-	resultTab.SetBodyContent(msg.Body)       // Body tab
+	resultTab.SetBody(msg.Body, msg.ContentType) // Body tab
+	a.recordResponse(msg.StatusCode)
+
+	// Show the result with headers first
+	a.revealResult(0)
+
+	// Count down to when the server allows the request to be sent again
+	if msg.HasRetry {
+		return a.startRetryCountdown(msg.RetryAfter)
+	}
+	return nil
+}
+
+// revealResult focuses the Result tab on innerTab. If the user kept working in the
+// Query tab while the request was running, focus stays there and the Result tab is
+// badged instead.
+func (a *App) revealResult(innerTab int) {
+	resultTab := a.tabContainer.GetResultTab()
+	resultTab.SwitchToInnerTab(innerTab)
 
-	// Activate the result tab and set it to show headers first
-	a.tabContainer.SetActive(true)
-	a.tabContainer.SwitchToTab(1) // Switch to Result tab (index 1)
-	resultTab.SwitchToInnerTab(0) // Ensure Headers tab is active (index 0)
-	resultTab.SetActive(true)     // Make sure the result tab is active
+	if a.keepQueryFocus && a.tabContainer.Active && a.tabContainer.ActiveTab == 0 {
+		a.tabContainer.MarkResultUnseen()
+		return
+	}
+	a.setFocus(focusResult)
+	resultTab.SetActive(true)
 }
 
 // View renders the current state of the application as a string.
 // It satisfies the tea.Model interface.
-func (a App) View() string {
+func (a *App) View() string {
 	if a.width == 0 {
 		return "Initializing..."
 	}
@@ -342,6 +670,41 @@ func (a App) View() string {
 		return a.renderToastOverlay()
 	}
 
+	// Check if the environment editor should be shown
+	if a.environmentEditor.Visible {
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.environmentEditor.View())
+	}
+
+	// Check if the text viewer should be shown
+	if a.textViewer.Visible {
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.textViewer.View())
+	}
+
+	// Check if the token inspector should be shown
+	if a.tokenInspector.Visible {
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.tokenInspector.View())
+	}
+
+	// Check if the scratchpad should be shown
+	if a.scratchpad.Visible {
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.scratchpad.View())
+	}
+
+	// Check if the time tool should be shown
+	if a.timeTool.Visible {
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.timeTool.View())
+	}
+
+	// Check if a prompt should be shown
+	if a.prompt.Visible {
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.prompt.View())
+	}
+
+	// Check if a picker should be shown
+	if a.picker.Visible {
+		return lipgloss.Place(a.width, a.height, lipgloss.Center, lipgloss.Center, a.picker.View())
+	}
+
 	// Check if spinner should be shown
 	if a.spinner.Visible {
 		return a.renderSpinnerOverlay(centeredView)
@@ -351,7 +714,7 @@ func (a App) View() string {
 }
 
 // renderMainView creates the main UI layout with banner, inputs, and tabs
-func (a App) renderMainView() string {
+func (a *App) renderMainView() string {
 
 
 	// Render the components
@@ -367,6 +730,11 @@ func (a App) renderMainView() string {
 	// Add a 2-line gap between the components for better spacing
 	fullView := lipgloss.JoinVertical(lipgloss.Left, "", topRow, "", tabBox)
 
+	// Show the selected environment and the variable under the cursor below the tabs
+	if statusBar := a.renderStatusBar(); statusBar != "" {
+		fullView = lipgloss.JoinVertical(lipgloss.Left, fullView, statusBar)
+	}
+
 	// Add 5% padding on each side for centering
 	paddingWidth := int(float64(a.width) * 0.05)
 
@@ -382,7 +750,7 @@ func (a App) renderMainView() string {
 
 
 // renderToastOverlay creates an overlay with a toast notification centered on the screen
-func (a App) renderToastOverlay() string {
+func (a *App) renderToastOverlay() string {
 	toastView := a.toast.View()
 
 	// Position the toast in the center of the screen
@@ -397,7 +765,7 @@ func (a App) renderToastOverlay() string {
 }
 
 // renderSpinnerOverlay creates an overlay with a spinner positioned over the URL input
-func (a App) renderSpinnerOverlay(baseView string) string {
+func (a *App) renderSpinnerOverlay(baseView string) string {
 	spinnerView := a.spinner.View()
 
 	// Calculate the line position of the URL input (3 lines from top: banner + empty line + title)
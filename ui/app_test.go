@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/env"
+	"github.com/RAshkettle/LazyPost/request"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestUpdateMutatesApp checks that Update works on the App it is called on and returns it,
+// so state set by one message is seen by the next.
+func TestUpdateMutatesApp(t *testing.T) {
+	app := NewApp(config.Config{})
+
+	model, _ := app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if model != app {
+		t.Fatal("Update returned a different model")
+	}
+	if app.width != 120 || app.height != 40 {
+		t.Errorf("size = %dx%d, want 120x40", app.width, app.height)
+	}
+
+	for _, r := range "http" {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got := app.urlInput.GetText(); got != "http" {
+		t.Errorf("URL = %q after typing, want %q", got, "http")
+	}
+}
+
+// TestResponseWhileEditingQuery checks that a response arriving while the user types in the
+// Query tab badges the Result tab instead of taking focus, and that it does take focus otherwise.
+func TestResponseWhileEditingQuery(t *testing.T) {
+	app := NewApp(config.Config{})
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.setFocus(focusQuery)
+
+	// Typing in the Query tab while a request is in flight
+	app.jobs.start(job{name: "Request", run: func(ctx context.Context, _ func(string)) (tea.Msg, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}})
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	app.jobs.cancelAll()
+
+	app.Update(RequestCompleteMsg{Headers: "Status: 200 OK"})
+	if app.tabContainer.ActiveTab != 0 {
+		t.Error("the response took focus from the Query tab")
+	}
+	if !app.tabContainer.HasUnseenResult() {
+		t.Error("the Result tab should be badged")
+	}
+
+	// Opening the Result tab clears the badge
+	app.setFocus(focusResult)
+	if app.tabContainer.HasUnseenResult() {
+		t.Error("the badge should be cleared once the Result tab is open")
+	}
+
+	// Without edits during the request the response is shown right away
+	app.setFocus(focusQuery)
+	app.keepQueryFocus = false
+	app.Update(RequestCompleteMsg{Headers: "Status: 200 OK"})
+	if app.tabContainer.ActiveTab != 1 {
+		t.Error("the response should switch to the Result tab")
+	}
+}
+
+// TestReadOnlyMode checks that read-only mode ignores typing into the form and refuses to send
+// or to replace the request.
+func TestReadOnlyMode(t *testing.T) {
+	app := NewApp(config.Config{ReadOnly: true})
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.urlInput.SetText("https://example.com")
+	app.setFocus(focusURL)
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if got := app.urlInput.GetText(); got != "https://example.com" {
+		t.Errorf("URL = %q, want it unchanged in read-only mode", got)
+	}
+
+	if _, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || app.jobs.running() {
+		t.Error("Enter should not send a request in read-only mode")
+	}
+	if app.handleSubmit() != nil || !app.toast.Visible {
+		t.Error("submitting should be refused with a toast in read-only mode")
+	}
+
+	// Importing or switching requests would replace the request in the form
+	for _, r := range []rune{'i', 'o'} {
+		app.toast.Hide()
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: true})
+		if app.picker.Visible || app.toast.Message != readOnlyMessage {
+			t.Errorf("Alt+%c should be refused in read-only mode", r)
+		}
+	}
+}
+
+// TestPrivacyMode tests that privacy mode masks secrets in the form and the response views,
+// and that secrets cannot be revealed until it is turned off.
+func TestPrivacyMode(t *testing.T) {
+	app := NewApp(config.Config{})
+	app.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	queryTab := app.tabContainer.GetQueryTab()
+	queryTab.HeadersInput.SetHeaders(map[string]string{"Authorization": "Bearer s3cret-token"})
+	resultTab := app.tabContainer.GetResultTab()
+	resultTab.SetHeadersContent("Set-Cookie: session=s3cret-cookie\n")
+
+	if !strings.Contains(queryTab.HeadersInput.View(), "s3cret-token") {
+		t.Fatal("header value should be visible outside privacy mode")
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}, Alt: true})
+	if !app.privacyMode {
+		t.Fatal("Alt+H should turn privacy mode on")
+	}
+	if strings.Contains(queryTab.HeadersInput.View(), "s3cret-token") {
+		t.Error("Authorization value is shown in privacy mode")
+	}
+	if view := resultTab.HeadersTab.View(); strings.Contains(view, "s3cret-cookie") {
+		t.Errorf("Set-Cookie value is shown in privacy mode:\n%s", view)
+	}
+
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}, Alt: true})
+	if app.secretsShown {
+		t.Error("secrets should stay hidden in privacy mode")
+	}
+
+	// The token inspector and the scratchpad open empty instead of showing secrets in full
+	queryTab.AuthInput.SetAuthType("OAuth2")
+	queryTab.AuthInput.SetOAuth2Token("s3cret-bearer")
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}, Alt: true})
+	if view := app.tokenInspector.View(); !app.tokenInspector.Visible || strings.Contains(view, "s3cret-bearer") {
+		t.Errorf("token inspector shows the auth token in privacy mode:\n%s", view)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	queryTab.AuthInput.SetAuthType("None")
+	app.urlInput.SetText("https://example.com/items?api_key=s3cret-url")
+	app.setFocus(focusURL)
+	app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}, Alt: true})
+	if view := app.scratchpad.View(); !app.scratchpad.Visible || strings.Contains(view, "s3cret-url") {
+		t.Errorf("scratchpad shows the focused URL in privacy mode:\n%s", view)
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// Secret variables expanded outside sensitive headers are masked in the request preview
+	app.SetEnvironments(env.Store{Active: "dev", Environments: []env.Environment{{
+		Name:      "dev",
+		Variables: []env.Variable{{Name: "key", Value: "s3cret/key", Secret: true}},
+	}}}, "")
+	app.urlInput.SetText("https://example.com/items?key={{key}}")
+	queryTab.QueryBodyInput.SetValue(`{"key": "{{key}}"}`)
+	app.handlePreviewRequest()
+	view := app.textViewer.View()
+	if strings.Contains(view, "s3cret") || !strings.Contains(view, "key=[REDACTED]") {
+		t.Errorf("secret variable is not masked in the request preview in privacy mode:\n%s", view)
+	}
+}
+
+// TestEscCancelsProjectionInput checks that Esc in the Body view's projection input closes
+// it without applying the edit, instead of quitting the app.
+func TestEscCancelsProjectionInput(t *testing.T) {
+	app := NewApp(config.Config{})
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.Update(RequestCompleteMsg{Body: []byte(`[{"id": 1, "name": "a"}]`), ContentType: "application/json"})
+	resultTab := app.tabContainer.GetResultTab()
+	resultTab.SwitchToInnerTab(1)
+	bodyTab := &resultTab.BodyTab
+
+	for _, r := range "fid" {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if !bodyTab.Editing() {
+		t.Fatal("'f' did not open the projection input")
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Fatal("Esc in the projection input quit the app")
+		}
+	}
+	if bodyTab.Editing() {
+		t.Error("Esc did not close the projection input")
+	}
+	if strings.Contains(bodyTab.View(), "Fields: id") {
+		t.Error("Esc applied the edited projection, want the previous one (none)")
+	}
+}
+
+// TestEscCancelsTableInput checks that Esc in the Body view's table input closes it without
+// copying a table, instead of quitting the app.
+func TestEscCancelsTableInput(t *testing.T) {
+	app := NewApp(config.Config{})
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	app.Update(RequestCompleteMsg{Body: []byte(`[{"id": 1, "name": "a"}]`), ContentType: "application/json"})
+	resultTab := app.tabContainer.GetResultTab()
+	resultTab.SwitchToInnerTab(1)
+	bodyTab := &resultTab.BodyTab
+
+	for _, r := range "cid" {
+		app.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if !bodyTab.Editing() {
+		t.Fatal("'c' did not open the table input")
+	}
+
+	_, cmd := app.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Fatal("Esc in the table input quit the app")
+		}
+	}
+	if bodyTab.Editing() {
+		t.Error("Esc did not close the table input")
+	}
+}
+
+// TestOpenRequest checks that an opened request is loaded with the Submit button focused,
+// so that Enter sends it.
+func TestOpenRequest(t *testing.T) {
+	app := NewApp(config.Config{})
+	app.OpenRequest(request.Request{Method: "DELETE", URL: "https://api.example.invalid/items/1"})
+
+	if got := app.urlInput.GetText(); got != "https://api.example.invalid/items/1" {
+		t.Errorf("URL = %q, want the opened request's", got)
+	}
+	if !app.submitButton.Active || app.urlInput.Active {
+		t.Fatal("the Submit button should be focused after opening a request")
+	}
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.spinner.Visible {
+		t.Error("Enter did not send the opened request")
+	}
+	app.jobs.cancelAll()
+}
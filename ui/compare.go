@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/diff"
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// promptMode identifies what the value entered in the App's prompt is used for.
+type promptMode int
+
+const (
+	promptNone          promptMode = iota
+	promptCompare                  // Entering the base URL to compare responses against
+	promptLatencyBudget            // Entering the latency budget of the current request
+)
+
+// volatileHeaders differ between any two responses and are left out of comparisons.
+var volatileHeaders = map[string]bool{
+	"Date": true,
+}
+
+// handleCompare asks for a second base URL to send the current request to.
+func (a *App) handleCompare() tea.Cmd {
+	a.promptMode = promptCompare
+	return a.prompt.Open("Compare against base URL", "https://staging.example.com", a.compareBaseURL)
+}
+
+// handlePromptSubmit applies the value entered in the prompt.
+func (a *App) handlePromptSubmit(value string) tea.Cmd {
+	mode := a.promptMode
+	a.promptMode = promptNone
+
+	switch mode {
+	case promptCompare:
+		value = strings.TrimSpace(value)
+		if !validateURL(value) {
+			a.toast.Show("Invalid URL: The compare base URL is not valid.")
+			return nil
+		}
+		a.compareBaseURL = value
+		return a.startCompare(value)
+
+	case promptLatencyBudget:
+		budget, err := parseLatencyBudget(value)
+		if err != nil {
+			a.toast.Show(err.Error())
+			return nil
+		}
+		a.latencyBudget = budget
+	}
+	return nil
+}
+
+// startCompare sends the current request to its own URL and to the same path on baseURL,
+// then shows a unified diff of the two responses in the Body result view.
+func (a *App) startCompare(baseURL string) tea.Cmd {
+	if !a.checkVariables() || !a.checkFields() {
+		return nil
+	}
+
+	rawURL := a.requestURL()
+	if !validateURL(rawURL) {
+		a.toast.Show("Invalid URL: The Provided URL is not valid.")
+		a.setFocus(focusURL)
+		return nil
+	}
+
+	prepared, err := a.prepareRequest(rawURL)
+	if err != nil {
+		a.toast.Show(fmt.Sprintf("Error building URL: %v", err))
+		return nil
+	}
+	// The other request is prepared for its own URL, so that it gets the host rules, route
+	// and correlation ID of the other host rather than those of the first
+	other, err := a.prepareRequest(retargetURL(rawURL, baseURL))
+	if err != nil {
+		a.toast.Show(fmt.Sprintf("Error building URL: %v", err))
+		return nil
+	}
+
+	a.setFocus(focusNone)
+	a.keepQueryFocus = false
+	spinnerCmd := a.spinner.Show("Comparing responses...")
+
+	return tea.Batch(spinnerCmd, a.jobs.start(compareJob(prepared, other)))
+}
+
+// compareJob returns the job that sends prepared and other, the same request prepared for
+// another base URL, one after the other and diffs their responses.
+func compareJob(prepared, other preparedRequest) job {
+	return job{name: "Comparison", run: func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		finalURL, headers, body, err := prepared.resolve()
+		if err != nil {
+			return nil, err
+		}
+		otherURL, otherHeaders, otherBody, err := other.resolve()
+		if err != nil {
+			return nil, err
+		}
+
+		progress("Sending to " + finalURL)
+		first, err := prepared.engine.send(ctx, prepared.method, finalURL, headers, body, prepared.route)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", finalURL, err)
+		}
+		progress("Sending to " + otherURL)
+		second, err := other.engine.send(ctx, other.method, otherURL, otherHeaders, otherBody, other.route)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", otherURL, err)
+		}
+
+		var summary strings.Builder
+		summary.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render("Compared:"), finalURL))
+		summary.WriteString(fmt.Sprintf("%s %s\n\n", styles.HeaderNameStyle.Render("Against:"), otherURL))
+		summary.WriteString(fmt.Sprintf("%s %s in %s\n", styles.HeaderNameStyle.Render("First:"), first.Status, formatLatency(first.Elapsed, prepared.budget)))
+		summary.WriteString(fmt.Sprintf("%s %s in %s\n", styles.HeaderNameStyle.Render("Second:"), second.Status, formatLatency(second.Elapsed, other.budget)))
+
+		return CompareCompleteMsg{
+			Summary: summary.String(),
+			Diff:    diff.Unified(finalURL, otherURL, comparableText(first), comparableText(second)),
+		}, nil
+	}}
+}
+
+// handleCompareCompleteMsg shows the result of a comparison in the Result tab.
+func (a *App) handleCompareCompleteMsg(msg CompareCompleteMsg) {
+	a.spinner.Hide()
+
+	body := msg.Diff
+	if body == "" {
+		body = "Responses are identical (ignoring the Date header)."
+	}
+
+	resultTab := a.tabContainer.GetResultTab()
+	resultTab.SetHeadersContent(msg.Summary)
+	resultTab.SetBodyContent(body)
+
+	a.revealResult(1) // Show the diff
+}
+
+// comparableText renders a response as text for diffing: the status line, the headers
+// sorted by name without volatile ones, and the body, indented if it is JSON.
+func comparableText(resp response) string {
+	var text strings.Builder
+	text.WriteString("Status: " + resp.Status + "\n")
+
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		if !volatileHeaders[http.CanonicalHeaderKey(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range resp.Header[name] {
+			text.WriteString(name + ": " + value + "\n")
+		}
+	}
+	text.WriteString("\n")
+
+	var indented bytes.Buffer
+	if json.Indent(&indented, resp.Body, "", "  ") == nil {
+		text.Write(indented.Bytes())
+	} else {
+		text.Write(resp.Body)
+	}
+	return text.String()
+}
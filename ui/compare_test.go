@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/fixtures"
+)
+
+// TestCompareJob checks that each side of a comparison is sent with the host rules of its
+// own host and its own correlation ID.
+func TestCompareJob(t *testing.T) {
+	first := fixtures.NewServer(t)
+	second := fixtures.NewServer(t)
+	app := NewApp(config.Config{
+		RequestIDHeader: "X-Request-ID",
+		HostRules:       []config.HostRule{{Host: "127.0.0.1", BearerToken: "first-only"}},
+	})
+	app.tabContainer.GetQueryTab().HeadersInput.SetHeaders(map[string]string{"X-Form": "both"})
+
+	rawURL := first.URL + "/echo"
+	otherBase := strings.Replace(second.URL, "127.0.0.1", "localhost", 1)
+	prepared, err := app.prepareRequest(rawURL)
+	if err != nil {
+		t.Fatalf("prepareRequest() error = %v", err)
+	}
+	other, err := app.prepareRequest(retargetURL(rawURL, otherBase))
+	if err != nil {
+		t.Fatalf("prepareRequest() error = %v", err)
+	}
+	if _, err := compareJob(prepared, other).run(context.Background(), func(string) {}); err != nil {
+		t.Fatalf("comparison error = %v", err)
+	}
+
+	firstRequest, _ := first.LastRequest()
+	secondRequest, ok := second.LastRequest()
+	if !ok {
+		t.Fatal("the other server got no request")
+	}
+	if got := firstRequest.Header.Get("Authorization"); got != "Bearer first-only" {
+		t.Errorf("first server got Authorization %q, want the host rule's token", got)
+	}
+	if got := secondRequest.Header.Get("Authorization"); got != "" {
+		t.Errorf("other server got Authorization %q, want the first host's token left out", got)
+	}
+	if got := secondRequest.Header.Get("X-Form"); got != "both" {
+		t.Errorf("other server got X-Form %q, want the form's headers", got)
+	}
+	firstID, secondID := firstRequest.Header.Get("X-Request-ID"), secondRequest.Header.Get("X-Request-ID")
+	if secondID == "" || secondID == firstID {
+		t.Errorf("other server got correlation ID %q, want a new one (first was %q)", secondID, firstID)
+	}
+}
@@ -33,7 +33,7 @@ func (c *APIKeyAuthDetailsComponent) SetSize(width, height int) {
 
 // Update handles messages and updates the component's state.
 // Currently, it's a no-op as the component is a placeholder.
-func (c APIKeyAuthDetailsComponent) Update(msg tea.Msg) tea.Cmd { return nil }
+func (c *APIKeyAuthDetailsComponent) Update(msg tea.Msg) tea.Cmd { return nil }
 
 // View renders the APIKeyAuthDetailsComponent.
 // It displays a placeholder message within a styled border.
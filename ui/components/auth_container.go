@@ -449,7 +449,8 @@ func (ac AuthContainer) View() string {
 // and the values entered in the corresponding auth detail component.
 // For "None", it returns an empty map. For other types, it retrieves credentials/tokens
 // and formats them into the appropriate "Authorization" header (or other headers for API Key, if applicable).
-// Placeholder comments indicate where logic for JWT, API Key, and OAuth2 needs to be fully implemented.
+// OAuth2 sends the access token obtained with the device flow, or pasted into the panel, as a Bearer token.
+// Placeholder comments indicate where logic for Bearer, JWT and API Key still needs to be implemented.
 func (ac AuthContainer) GetAuthHeaders() map[string]string {
 	headers := make(map[string]string)
 	selectedType := ac.authSelector.options[ac.authSelector.selectedIndex]
@@ -480,18 +481,91 @@ func (ac AuthContainer) GetAuthHeaders() map[string]string {
 		// 	 if addTo == "header" { headers[headerName] = headerValue } ... else if query etc.
 		// }
 	case "OAuth2":
-		// TODO: Implement OAuth2 token retrieval from oauth2AuthDetails
-		// This will likely be more complex, involving a token that might be stored
-		// accessToken := ac.oauth2AuthDetails.GetAccessToken()
-		// if accessToken != "" {
-		// 	headers["Authorization"] = "Bearer " + accessToken
-		// }
+		// The token comes from the device flow or was pasted into the panel
+		accessToken := ac.oauth2AuthDetails.GetAccessToken()
+		if accessToken != "" {
+			headers["Authorization"] = "Bearer " + accessToken
+		}
 	case "None":
 		// No headers to add
 	}
 	return headers
 }
 
+// GetAuthType returns the name of the selected authentication type, e.g. "None" or "Basic".
+func (ac AuthContainer) GetAuthType() string {
+	return ac.authSelector.options[ac.authSelector.selectedIndex]
+}
+
+// FieldErrors returns the validation problems of the fields of the selected auth type.
+func (ac AuthContainer) FieldErrors() []FieldError {
+	switch ac.GetAuthType() {
+	case "Basic":
+		return ac.basicAuthDetails.FieldErrors()
+	case "OAuth2":
+		return ac.oauth2AuthDetails.FieldErrors()
+	}
+	return nil
+}
+
+// SetSecretsMasked sets whether the Basic password, Bearer token and OAuth2 access token
+// are shown as asterisks.
+func (ac *AuthContainer) SetSecretsMasked(masked bool) {
+	ac.basicAuthDetails.SetMasked(masked)
+	ac.tokenAuthDetails.SetMasked(masked)
+	ac.oauth2AuthDetails.SetMasked(masked)
+}
+
+// SetAuthType selects the authentication type with the given name.
+// It returns false and leaves the selection unchanged if the name is not a known auth type.
+func (ac *AuthContainer) SetAuthType(authType string) bool {
+	for i, option := range ac.authSelector.options {
+		if option == authType {
+			ac.authSelector.selectedIndex = i
+			ac.authSelector.highlightedIndex = i
+			ac.SetActive(ac.Active) // Re-evaluate active detail component
+			return true
+		}
+	}
+	return false
+}
+
+// GetBasicCredentials returns the username and password entered for Basic auth.
+func (ac *AuthContainer) GetBasicCredentials() (username string, password string) {
+	return ac.basicAuthDetails.GetValues()
+}
+
+// SetBasicCredentials sets the username and password for Basic auth.
+func (ac *AuthContainer) SetBasicCredentials(username, password string) {
+	ac.basicAuthDetails.SetValues(username, password)
+}
+
+// GetBearerToken returns the token entered for Bearer auth.
+func (ac *AuthContainer) GetBearerToken() string {
+	return ac.tokenAuthDetails.GetToken()
+}
+
+// SetBearerToken sets the token for Bearer auth.
+func (ac *AuthContainer) SetBearerToken(token string) {
+	ac.tokenAuthDetails.SetToken(token)
+}
+
+// GetOAuth2Token returns the access token of the OAuth2 panel.
+func (ac *AuthContainer) GetOAuth2Token() string {
+	return ac.oauth2AuthDetails.GetAccessToken()
+}
+
+// SetOAuth2Token sets the access token of the OAuth2 panel.
+func (ac *AuthContainer) SetOAuth2Token(token string) {
+	ac.oauth2AuthDetails.SetAccessToken(token)
+}
+
+// UpdateOAuth2Flow passes progress of an OAuth2 device flow to the OAuth2 panel.
+// Unlike Update, it works while the container is inactive so that polling continues in the background.
+func (ac *AuthContainer) UpdateOAuth2Flow(msg OAuth2FlowMsg) tea.Cmd {
+	return ac.oauth2AuthDetails.HandleFlowMsg(msg)
+}
+
 // IsFocused checks if the AuthContainer itself is considered to be in a focused state.
 // Currently, this is equivalent to its Active state.
 // (Placeholder for potentially more complex focus logic).
@@ -32,6 +32,7 @@ func NewBasicAuthDetailsComponent() BasicAuthDetailsComponent {
 	username.Placeholder = "Enter username"
 	username.Prompt = "Username: "
 	username.Width = 30 // Width of the text area
+	username.Validate = ValidateBasicUsername
 	// username.Focus() // Initial focus will be handled by Update or SetActive
 
 	password := textinput.New()
@@ -48,6 +49,19 @@ func NewBasicAuthDetailsComponent() BasicAuthDetailsComponent {
 	}
 }
 
+// echoMode returns the echo mode of a secret input: asterisks when masked, plain text otherwise.
+func echoMode(masked bool) textinput.EchoMode {
+	if masked {
+		return textinput.EchoPassword
+	}
+	return textinput.EchoNormal
+}
+
+// SetMasked sets whether the password is shown as asterisks.
+func (c *BasicAuthDetailsComponent) SetMasked(masked bool) {
+	c.passwordInput.EchoMode = echoMode(masked)
+}
+
 // SetActive sets the active state of the component.
 // When active, it focuses the appropriate input field (username or password).
 // When inactive, it blurs both input fields.
@@ -175,8 +189,11 @@ func (c BasicAuthDetailsComponent) View() string {
 	// Join the styled input fields vertically
 	inputsView := lipgloss.JoinVertical(lipgloss.Left, styledUsernameView, styledPasswordView)
 
-	// Help text
+	// Show what is wrong with the user name in place of the help text
 	helpTextView := styles.DefaultTheme.HelpTextStyle.Foreground(styles.BrightYellow).Render("Tab/Shift+Tab or Up/Down to navigate fields.")
+	if err := inputError(c.usernameInput); err != nil {
+		helpTextView = renderInputError(err)
+	}
 
 	// Combine inputs and help text
 	contentWithHelp := lipgloss.JoinVertical(
@@ -214,6 +231,20 @@ func (c BasicAuthDetailsComponent) View() string {
 	return finalView
 }
 
+// SetValues replaces the username and password input values.
+func (c *BasicAuthDetailsComponent) SetValues(username, password string) {
+	c.usernameInput.SetValue(username)
+	c.passwordInput.SetValue(password)
+}
+
+// FieldErrors returns the validation problems of the username and password fields.
+func (c BasicAuthDetailsComponent) FieldErrors() []FieldError {
+	if err := inputError(c.usernameInput); err != nil {
+		return []FieldError{{Field: "Basic auth user name", Err: err}}
+	}
+	return nil
+}
+
 // GetValues returns the current values of the username and password input fields.
 func (c *BasicAuthDetailsComponent) GetValues() (username string, password string) {
 	return c.usernameInput.Value(), c.passwordInput.Value()
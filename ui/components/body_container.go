@@ -1,26 +1,72 @@
 package components
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"mime"
+	"os"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/RAshkettle/LazyPost/ui/styles"
 	"github.com/atotto/clipboard" // Added for clipboard functionality
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // BodyContainer represents a scrollable component for displaying HTTP response bodies.
 // It uses a viewport for scrolling through large content.
 type BodyContainer struct {
-	Viewport   viewport.Model // Viewport for scrollable content
-	rawContent string         // Store raw content for copying
-	Width      int            // Width of the component in characters
-	Height     int            // Height of the component in characters
-	Active     bool           // Whether the component is currently active/focused
+	Viewport    viewport.Model  // Viewport for scrollable content
+	rawContent  string          // Store raw content for copying
+	rawBytes    []byte          // Unmodified body bytes, used for base64 copy and saving to file
+	contentType string          // Content-Type of the body, used to pick a file extension when saving
+	isBinary    bool            // Whether the body is binary and shown as a summary instead of text
+	noWrap      bool            // Whether content is laid out as a table and scrolls horizontally instead of wrapping
+	pager       *jsonArrayPager // Pager for large JSON array bodies, nil when the body is shown whole
+	Width       int             // Width of the component in characters
+	Height      int             // Height of the component in characters
+	Active      bool            // Whether the component is currently active/focused
+
+	projectionInput   textinput.Model // Input for the comma-separated key paths of a JSON projection
+	editingProjection bool            // Whether the projection input is open and receiving keys
+	projection        string          // Currently applied projection, empty when the full body is shown
+
+	tableInput     textinput.Model // Input for the JSONPaths of the columns to copy as a table
+	editingTable   bool            // Whether the table input is open and receiving keys
+	tableDelimiter rune            // Delimiter of the table being copied: ',' for CSV or '\t' for TSV
+
+	copyFilter    func(string) string // Applied to text before it is copied, e.g. to redact credentials
+	displayFilter func(string) string // Applied to text before it is shown, e.g. to mask secrets in privacy mode
+
+	omittedBytes int  // Bytes of a large text body left out of the display, 0 when it is shown whole
+	showFullBody bool // Whether the display limit is lifted for the current body
+
+	displayContent  string   // Unwrapped text currently shown, re-wrapped when the width changes
+	lines           []string // Lines of the viewport after wrapping
+	continued       []bool   // Whether each line is a soft-wrapped continuation of the previous one
+	selecting       bool     // Whether a range of lines is selected for copying
+	selectionAnchor int      // Line where the selection started
+	selectionCursor int      // Line the selection extends to, moved with shift+up/down
+
+	highlightJSON bool // Whether the displayed content is JSON and shown with syntax highlighting
 }
 
+// selectionStyle highlights the selected lines.
+var selectionStyle = lipgloss.NewStyle().Reverse(true)
+
+// maxDisplayBytes is the largest text body rendered in full. Larger bodies are cut
+// to keep the viewport responsive; the full body is still copied and saved.
+const maxDisplayBytes = 1 << 20
+
 // NewBodyContainer creates a new body container with a scrollable viewport.
 func NewBodyContainer() BodyContainer {
 	vp := viewport.New(0, 0)
@@ -37,71 +83,387 @@ func NewBodyContainer() BodyContainer {
 		HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u")),
 		HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d")),
 	}
+	vp.SetHorizontalStep(4) // Used by wide content such as CSV tables
+
+	projectionInput := textinput.New()
+	projectionInput.Prompt = "Fields: "
+	projectionInput.Placeholder = "id, user.name (empty to show all)"
+	projectionInput.CharLimit = 256
+
+	tableInput := textinput.New()
+	tableInput.Placeholder = "$.items[*].id, $.items[*].user.name"
+	tableInput.CharLimit = 512
 
 	return BodyContainer{
-		Viewport:   vp,
-		rawContent: "Response body will be displayed here.", // Initialize rawContent
-		Width:      0,
-		Height:     0,
-		Active:     false,
+		Viewport:        vp,
+		projectionInput: projectionInput,
+		tableInput:      tableInput,
+		rawContent:      "Response body will be displayed here.", // Initialize rawContent
+		Width:           0,
+		Height:          0,
+		Active:          false,
 	}
 }
 
+// SetCopyFilter sets a function applied to text bodies before they are copied to the clipboard.
+// Binary bodies copied as base64 and bodies saved to a file are left unchanged.
+func (b *BodyContainer) SetCopyFilter(filter func(string) string) {
+	b.copyFilter = filter
+}
+
+// SetDisplayFilter sets a function applied to text bodies before they are shown, and lays the
+// current body out again. nil shows bodies as they are.
+func (b *BodyContainer) SetDisplayFilter(filter func(string) string) {
+	b.displayFilter = filter
+	b.rewrap()
+}
+
 // SetContent updates the body content to display and resets scroll position.
 func (b *BodyContainer) SetContent(content string) {
 	b.rawContent = content // Store raw content
+	b.rawBytes = []byte(content)
+	b.contentType = ""
+	b.isBinary = false
+	b.noWrap = false
+	b.pager = nil
+	b.projection = ""
+	b.omittedBytes = 0
+	b.highlightJSON = false
+	b.renderContent(content)
+}
+
+// renderContent places content into the viewport, wrapping it unless noWrap is set,
+// and resets the scroll position and selection.
+func (b *BodyContainer) renderContent(content string) {
+	b.displayContent = content
+	b.selecting = false
+
 	// Make sure we have valid dimensions before setting content
 	if b.Width > 0 && b.Height > 0 {
-		// Store the content and ensure the viewport is properly sized
-		effectiveWidth := b.Width - 4  // Account for 2 chars padding on both sides plus border
+		// Ensure the viewport is properly sized
 		b.Viewport.Width = b.Width - 2 // Account for border padding
 		b.Viewport.Height = b.Height - 2
 
-		// Apply text wrapping to ensure content fits within the viewport width
-		wrappedContent := wrapText(content, effectiveWidth)
-
 		// Set the wrapped content and reset the scroll position
-
-		b.Viewport.SetContent(wrappedContent)
+		b.layoutContent()
 		b.Viewport.GotoTop()
+		b.Viewport.SetXOffset(0)
 	} else {
 		// Just store the content for now, the viewport will be updated when dimensions are set
+		b.lines = nil
+		b.continued = nil
 		b.Viewport.SetContent(content) // Keep this for initial placeholder
 	}
 }
 
-// wrapText wraps the text to ensure it fits within the specified width.
-// This ensures all content is visible and properly formatted within the viewport.
-func wrapText(content string, width int) string {
-	if width <= 0 {
-		return content
+// layoutContent wraps displayContent, passed through the display filter if there is one,
+// to the current width, unless noWrap is set,
+// and places it into the viewport without changing the scroll position.
+func (b *BodyContainer) layoutContent() {
+	content := b.displayContent
+	if b.displayFilter != nil && !b.isBinary {
+		content = b.displayFilter(content)
+	}
+	if b.noWrap {
+		b.lines = strings.Split(content, "\n")
+		b.continued = make([]bool, len(b.lines))
+	} else {
+		effectiveWidth := b.Width - 4 // Account for 2 chars padding on both sides plus border
+		b.lines, b.continued = wrapLines(content, effectiveWidth)
+	}
+	if b.highlightJSON {
+		b.Viewport.SetContent(strings.Join(highlightLines(content, b.lines, b.continued, styles.Syntax), "\n"))
+		return
 	}
+	b.Viewport.SetContent(strings.Join(b.lines, "\n"))
+}
 
-	var result strings.Builder
-	lines := strings.Split(content, "\n")
+// rewrap lays the content out again after a size change, keeping the scroll position.
+// The selection is dropped, since line numbers change with the wrapping.
+func (b *BodyContainer) rewrap() {
+	if b.displayContent == "" || b.Width <= 2 {
+		return
+	}
+	offset := b.Viewport.YOffset
+	b.selecting = false
+	b.layoutContent()
+	b.Viewport.SetYOffset(offset)
+}
 
-	for i, line := range lines {
-		if len(line) <= width {
-			result.WriteString(line)
-		} else {
-			// Wrap lines longer than width
-			for j := 0; j < len(line); j += width {
-				end := j + width
-				end = min(end, len(line))
-
-				result.WriteString(line[j:end])
-				if end < len(line) {
-					result.WriteString("\n")
-				}
-			}
+// extendSelection moves the end of the line selection by delta lines, keeping it visible.
+// The first call selects the top visible line when moving down and the bottom one when moving up.
+func (b *BodyContainer) extendSelection(delta int) {
+	if len(b.lines) == 0 {
+		return
+	}
+	if !b.selecting {
+		line := b.Viewport.YOffset
+		if delta < 0 {
+			line = min(b.Viewport.YOffset+b.Viewport.Height, len(b.lines)) - 1
 		}
-		// Add newline after each original line except the last one
-		if i < len(lines)-1 {
-			result.WriteString("\n")
+		b.selecting = true
+		b.selectionAnchor, b.selectionCursor = line, line
+		return
+	}
+
+	b.selectionCursor = max(0, min(b.selectionCursor+delta, len(b.lines)-1))
+	if b.selectionCursor < b.Viewport.YOffset {
+		b.Viewport.SetYOffset(b.selectionCursor)
+	} else if b.selectionCursor >= b.Viewport.YOffset+b.Viewport.Height {
+		b.Viewport.SetYOffset(b.selectionCursor - b.Viewport.Height + 1)
+	}
+}
+
+// selectionRange returns the first and last selected line.
+func (b *BodyContainer) selectionRange() (int, int) {
+	return min(b.selectionAnchor, b.selectionCursor), max(b.selectionAnchor, b.selectionCursor)
+}
+
+// SelectedText returns the text of the selected lines, or false if nothing is selected.
+// Lines that were soft-wrapped for display are joined back together, so a long line
+// is copied as it appears in the response.
+func (b *BodyContainer) SelectedText() (string, bool) {
+	if !b.selecting {
+		return "", false
+	}
+	start, end := b.selectionRange()
+
+	var text strings.Builder
+	for i := start; i <= end && i < len(b.lines); i++ {
+		if i > start && !b.continued[i] {
+			text.WriteString("\n")
 		}
+		text.WriteString(b.lines[i])
 	}
+	return ansi.Strip(text.String()), true
+}
 
-	return result.String()
+// ClearSelection drops the line selection and reports whether there was one.
+func (b *BodyContainer) ClearSelection() bool {
+	had := b.selecting
+	b.selecting = false
+	return had
+}
+
+// SetBody updates the body from raw response bytes.
+// Text bodies are displayed as-is. Binary bodies are replaced by a short summary,
+// since rendering them would garble the viewport, and are kept intact for the
+// base64 copy and save-to-file actions. Text bodies over maxDisplayBytes are cut,
+// with the omitted byte count shown, until the full body is loaded with 'L'.
+func (b *BodyContainer) SetBody(body []byte, contentType string) {
+	b.showFullBody = false
+	b.setBody(body, contentType)
+}
+
+// setBody renders body without resetting whether the display limit is lifted.
+func (b *BodyContainer) setBody(body []byte, contentType string) {
+	// Large JSON arrays are paged, so only other text bodies need the display limit
+	pager, paged := newJSONArrayPager(body)
+	shown, omitted := body, 0
+	if !b.showFullBody && !paged && !isBinaryContent(body) {
+		shown, omitted = truncateForDisplay(body, maxDisplayBytes)
+	}
+
+	// CSV/TSV bodies are shown as an aligned table; copying still uses the raw text
+	if delimiter, ok := delimiterForContentType(contentType); ok && !isBinaryContent(body) {
+		if table, err := renderDelimitedTable(string(shown), delimiter); err == nil {
+			b.SetContent(string(body))
+			b.contentType = contentType
+			b.noWrap = true
+			b.omittedBytes = omitted
+			b.renderContent(table + b.truncationNote())
+			return
+		}
+	}
+
+	// Large JSON arrays are shown one page at a time to keep the viewport responsive
+	if paged {
+		b.SetContent(string(body))
+		b.contentType = contentType
+		b.pager = pager
+		b.highlightJSON = true
+		b.renderContent(pager.render())
+		return
+	}
+
+	if !isBinaryContent(body) {
+		b.SetContent(string(body))
+		b.contentType = contentType
+		b.omittedBytes = omitted
+		b.highlightJSON = isJSONContentType(contentType) || json.Valid(body)
+		if omitted > 0 || b.highlightJSON {
+			b.renderContent(string(shown) + b.truncationNote())
+		}
+		return
+	}
+
+	summary := fmt.Sprintf("Binary response body (%d bytes", len(body))
+	if contentType != "" {
+		summary += ", " + contentType
+	}
+	summary += ").\n\nPress 'b' to copy it as base64 or 's' to save it to a file."
+
+	b.SetContent(summary)
+	b.rawBytes = body
+	b.contentType = contentType
+	b.isBinary = true
+}
+
+// copyTable copies the columns listed in spec, JSONPaths over an array in the body, to the
+// clipboard as a table with the current delimiter, for pasting into spreadsheets or messages.
+// The spec stays in the input for the next copy.
+func (b *BodyContainer) copyTable(spec string) tea.Cmd {
+	if strings.TrimSpace(spec) == "" {
+		return nil
+	}
+	table, rows, err := extractTable(b.rawBytes, spec, b.tableDelimiter)
+	if err != nil {
+		return ShowToast(fmt.Sprintf("Table extraction failed: %v", err))
+	}
+	if b.copyFilter != nil {
+		table = b.copyFilter(table)
+	}
+	if err := clipboard.WriteAll(table); err != nil {
+		return ShowToast(fmt.Sprintf("Error copying to clipboard: %v", err))
+	}
+	format := "CSV"
+	if b.tableDelimiter == '\t' {
+		format = "TSV"
+	}
+	return ShowToast(fmt.Sprintf("Copied %d rows as %s", rows, format))
+}
+
+// applyProjection shows only the key paths listed in spec for a JSON body.
+// An empty spec restores the full body. Projected arrays are paged like any other large array.
+func (b *BodyContainer) applyProjection(spec string) tea.Cmd {
+	if b.isBinary {
+		return ShowToast("Projection is only available for JSON bodies")
+	}
+
+	paths := parseProjection(spec)
+	if len(paths) == 0 {
+		b.setBody(b.rawBytes, b.contentType)
+		return nil
+	}
+
+	projected, err := projectJSON(b.rawBytes, paths)
+	if err != nil {
+		return ShowToast(fmt.Sprintf("Projection failed: %v", err))
+	}
+
+	b.projection = spec
+	b.noWrap = false
+	b.highlightJSON = true
+	if pager, ok := newJSONArrayPager(projected); ok {
+		b.pager = pager
+		b.renderContent(pager.render())
+	} else {
+		b.pager = nil
+		b.renderContent(indentJSON(projected))
+	}
+	return nil
+}
+
+// loadFullBody lifts the display limit and renders the entire body.
+func (b *BodyContainer) loadFullBody() tea.Cmd {
+	if b.omittedBytes == 0 {
+		return nil
+	}
+	b.showFullBody = true
+	b.setBody(b.rawBytes, b.contentType)
+	return ShowToast(fmt.Sprintf("Loaded entire body (%d bytes)", len(b.rawBytes)))
+}
+
+// truncationNote returns the line appended to a cut body, or "" when nothing was omitted.
+func (b *BodyContainer) truncationNote() string {
+	if b.omittedBytes == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\n… %d of %d bytes omitted. Press 'L' to load the entire body or 's' to save it to a file.",
+		b.omittedBytes, len(b.rawBytes))
+}
+
+// truncateForDisplay cuts data to at most limit bytes, preferring to end on a line break
+// in the second half of the limit and never splitting a UTF-8 sequence.
+// It returns the kept bytes and how many were omitted.
+func truncateForDisplay(data []byte, limit int) ([]byte, int) {
+	if len(data) <= limit {
+		return data, 0
+	}
+
+	cut := limit
+	if i := bytes.LastIndexByte(data[:limit], '\n'); i >= limit/2 {
+		cut = i + 1
+	} else {
+		for cut > 0 && !utf8.RuneStart(data[cut]) {
+			cut--
+		}
+	}
+	return data[:cut], len(data) - cut
+}
+
+// isBinaryContent reports whether data should be treated as binary.
+// Anything that is not valid UTF-8 or contains NUL bytes is considered binary.
+func isBinaryContent(data []byte) bool {
+	return !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0
+}
+
+// responseFileName builds a timestamped file name for a saved response body.
+// The extension is derived from the content type, falling back to .bin (binary) or .txt.
+func responseFileName(contentType string, binary bool, now time.Time) string {
+	ext := ".txt"
+	if binary {
+		ext = ".bin"
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+			ext = exts[0]
+		}
+	}
+	return "lazypost-response-" + now.Format("20060102-150405") + ext
+}
+
+// copyBase64 copies the raw body bytes to the clipboard as standard base64.
+func (b *BodyContainer) copyBase64() tea.Cmd {
+	encoded := base64.StdEncoding.EncodeToString(b.rawBytes)
+	if err := clipboard.WriteAll(encoded); err != nil {
+		return ShowToast(fmt.Sprintf("Error copying to clipboard: %v", err))
+	}
+	return nil
+}
+
+// saveToFile writes the raw body bytes to a timestamped file in the working directory.
+// The write happens in a background job and reports the result through a toast.
+func (b *BodyContainer) saveToFile() tea.Cmd {
+	data := b.rawBytes
+	name := responseFileName(b.contentType, b.isBinary, time.Now())
+	return RunJob("Saving the body", func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		if err := os.WriteFile(name, data, 0o644); err != nil {
+			return ShowToastMsg{Message: fmt.Sprintf("Error saving body: %v", err)}, nil
+		}
+		return ShowToastMsg{Message: fmt.Sprintf("Saved %d bytes to %s", len(data), name)}, nil
+	})
+}
+
+// wrapLines wraps the text to ensure it fits within the specified width.
+// This ensures all content is visible and properly formatted within the viewport.
+// continued reports for each returned line whether it continues the previous one
+// because it was wrapped, rather than starting a new line of the original text.
+func wrapLines(content string, width int) (lines []string, continued []bool) {
+	for _, line := range strings.Split(content, "\n") {
+		if width <= 0 || len(line) <= width {
+			lines = append(lines, line)
+			continued = append(continued, false)
+			continue
+		}
+		// Wrap lines longer than width
+		for j := 0; j < len(line); j += width {
+			end := min(j+width, len(line))
+			lines = append(lines, line[j:end])
+			continued = append(continued, j > 0)
+		}
+	}
+	return lines, continued
 }
 
 // SetWidth sets the width of the component in characters.
@@ -111,12 +473,7 @@ func (b *BodyContainer) SetWidth(width int) {
 		b.Viewport.Width = width - 2 // Account for border padding
 
 		// Re-wrap content when width changes if we have content
-		content := b.Viewport.View()
-		if content != "" && content != "Response body will be displayed here." {
-			effectiveWidth := width - 6 // Account for 2 chars padding on both sides plus border
-			wrappedContent := wrapText(content, effectiveWidth)
-			b.Viewport.SetContent(wrappedContent)
-		}
+		b.rewrap()
 	}
 }
 
@@ -130,9 +487,26 @@ func (b *BodyContainer) SetHeight(height int) {
 	}
 }
 
-// SetActive sets the active state of the component.
+// SetActive sets the active state of the component. Losing focus closes an open input
+// without applying it.
 func (b *BodyContainer) SetActive(active bool) {
 	b.Active = active
+	if !active {
+		b.cancelEditing()
+	}
+}
+
+// Editing reports whether an input of the Body view is open. It then receives every key,
+// including Esc, which closes it without applying it.
+func (b *BodyContainer) Editing() bool {
+	return b.Active && (b.editingProjection || b.editingTable)
+}
+
+// cancelEditing closes an open input without applying it, keeping the current projection.
+func (b *BodyContainer) cancelEditing() {
+	b.editingProjection, b.editingTable = false, false
+	b.projectionInput.Blur()
+	b.tableInput.Blur()
 }
 
 // Update handles viewport navigation and other messages.
@@ -147,22 +521,8 @@ func (b *BodyContainer) Update(msg tea.Msg) tea.Cmd {
 			b.Viewport.Width = b.Width - 2
 			b.Viewport.Height = b.Height - 2
 
-			// Re-wrap content based on new width
-			origContent := b.Viewport.View()
-			if origContent != "" && origContent != "Response body will be displayed here." {
-				// Save current scroll position
-				currentPosition := b.Viewport.YOffset
-
-				// Re-wrap text for new dimensions
-				effectiveWidth := b.Width - 6 // Account for 2 chars padding on both sides plus border
-				wrappedContent := wrapText(origContent, effectiveWidth)
-				b.Viewport.SetContent(wrappedContent)
-
-				// Try to restore scroll position (approximately)
-				if currentPosition > 0 && currentPosition < b.Viewport.TotalLineCount() {
-					b.Viewport.YOffset = currentPosition
-				}
-			}
+			// Re-wrap content based on new width, keeping the scroll position
+			b.rewrap()
 		}
 	}
 
@@ -173,20 +533,110 @@ func (b *BodyContainer) Update(msg tea.Msg) tea.Cmd {
 
 	switch msgType := msg.(type) {
 	case tea.KeyMsg:
+		// While the projection input is open it receives every key
+		if b.editingProjection {
+			switch msgType.String() {
+			case "enter":
+				b.editingProjection = false
+				b.projectionInput.Blur()
+				return b.applyProjection(b.projectionInput.Value())
+			case "esc":
+				b.cancelEditing()
+				return nil
+			}
+			b.projectionInput, cmd = b.projectionInput.Update(msg)
+			return cmd
+		}
+		// While the table input is open it receives every key
+		if b.editingTable {
+			switch msgType.String() {
+			case "enter":
+				b.editingTable = false
+				b.tableInput.Blur()
+				return b.copyTable(b.tableInput.Value())
+			case "esc":
+				b.cancelEditing()
+				return nil
+			}
+			b.tableInput, cmd = b.tableInput.Update(msg)
+			return cmd
+		}
+
 		switch msgType.String() {
+		case "f":
+			// Open the projection input, pre-filled with the current projection
+			b.editingProjection = true
+			b.projectionInput.SetValue(b.projection)
+			b.projectionInput.CursorEnd()
+			return b.projectionInput.Focus()
+		case "c", "C":
+			// Open the table input to copy fields of a JSON array as CSV ('c') or TSV ('C')
+			if !b.highlightJSON {
+				return ShowToast("Copying a table is only available for JSON bodies")
+			}
+			b.editingTable = true
+			b.tableDelimiter = ','
+			b.tableInput.Prompt = "CSV columns: "
+			if msgType.String() == "C" {
+				b.tableDelimiter = '\t'
+				b.tableInput.Prompt = "TSV columns: "
+			}
+			b.tableInput.CursorEnd()
+			return b.tableInput.Focus()
 		case "y":
 			if b.Active {
-				err := clipboard.WriteAll(b.rawContent)
+				// Binary bodies cannot survive the clipboard's text path, so copy them as base64
+				if b.isBinary {
+					return b.copyBase64()
+				}
+				// Copy only the selected lines if there is a selection
+				text, selected := b.SelectedText()
+				if !selected {
+					text = b.rawContent
+				}
+				if b.copyFilter != nil {
+					text = b.copyFilter(text)
+				}
+				err := clipboard.WriteAll(text)
 				if err != nil {
-					// Optionally, you could send a message back to the app to show a toast
-					// For now, just print to stderr or log
-					fmt.Println("Error copying to clipboard:", err)
+					return ShowToast(fmt.Sprintf("Error copying to clipboard: %v", err))
 				}
-				// We might want to provide feedback to the user, e.g., a short message
-				// This could be a new tea.Msg that the main app handles.
-				// For simplicity, returning nil for now.
 				return nil
 			}
+		case "b":
+			// Copy the body as base64
+			return b.copyBase64()
+		case "s":
+			// Save the unmodified body bytes to a file
+			return b.saveToFile()
+		case "t":
+			// Switch to the next syntax theme for JSON bodies
+			theme := styles.NextSyntaxTheme()
+			b.rewrap()
+			return ShowToast("Syntax theme: " + theme.Name)
+		case "L":
+			// Render a body that was cut by the display limit in full
+			return b.loadFullBody()
+		case "n":
+			// Next page of a paged JSON array
+			if b.pager != nil && b.pager.next() {
+				b.renderContent(b.pager.render())
+			}
+			return nil
+		case "p":
+			// Previous page of a paged JSON array
+			if b.pager != nil && b.pager.prev() {
+				b.renderContent(b.pager.render())
+			}
+			return nil
+		case "shift+down":
+			// Start or extend the line selection downwards
+			b.extendSelection(1)
+			return nil
+		case "shift+up":
+			// Start or extend the line selection upwards
+			b.extendSelection(-1)
+			return nil
 		case "home":
 			// Jump to the top of the content
 			b.Viewport.GotoTop()
@@ -195,7 +645,7 @@ func (b *BodyContainer) Update(msg tea.Msg) tea.Cmd {
 			// Jump to the bottom of the content
 			b.Viewport.GotoBottom()
 			return nil
-		case "up", "k", "down", "j", "pgup", "pgdn", "ctrl+u", "ctrl+d":
+		case "up", "k", "down", "j", "pgup", "pgdn", "ctrl+u", "ctrl+d", "left", "h", "right", "l":
 			// Let viewport handle other navigation keys
 			b.Viewport, cmd = b.Viewport.Update(msg)
 			cmds = append(cmds, cmd)
@@ -232,8 +682,8 @@ func (b BodyContainer) View() string {
 		return ""
 	}
 
-	// Get viewport content and add padding
-	content := addPadding(b.Viewport.View(), 2)
+	// Get viewport content, highlight selected lines and add padding
+	content := addPadding(b.highlightSelection(b.Viewport.View()), 2)
 
 	// Show scrolling help text when body is active
 	if b.Active {
@@ -260,14 +710,71 @@ func (b BodyContainer) View() string {
 			}
 		}
 
-		helpParts = append(helpParts, "'y' to copy")
+		if b.noWrap {
+			helpParts = append(helpParts, "←/→ to scroll columns")
+		}
+
+		if b.pager != nil {
+			helpParts = append(helpParts, b.pager.indicator()+" • 'n'/'p' to page")
+		}
+
+		if b.omittedBytes > 0 {
+			helpParts = append(helpParts, fmt.Sprintf("%d bytes omitted • 'L' to load all", b.omittedBytes))
+		}
+
+		if b.selecting {
+			start, end := b.selectionRange()
+			helpParts = append(helpParts, fmt.Sprintf("%d lines selected • 'y' to copy them • Esc to clear", end-start+1))
+		} else if !b.isBinary {
+			helpParts = append(helpParts, "Shift+↑/↓ to select lines")
+		}
+
+		if b.highlightJSON {
+			helpParts = append(helpParts, "'t' theme: "+styles.Syntax.Name)
+		}
+
+		if b.projection != "" {
+			helpParts = append(helpParts, "Fields: "+b.projection)
+		}
+		helpParts = append(helpParts, "'f' to pick fields")
+		if b.highlightJSON {
+			helpParts = append(helpParts, "'c'/'C' to copy a CSV/TSV table")
+		}
+
+		if b.isBinary {
+			helpParts = append(helpParts, "'y'/'b' to copy as base64 • 's' to save")
+		} else {
+			helpParts = append(helpParts, "'y' to copy • 'b' base64 • 's' to save")
+		}
 
 		helpText := strings.Join(helpParts, " • ")
 
 		if helpText != "" {
 			content = lipgloss.JoinVertical(lipgloss.Left, content, helpStyle.Render(helpText))
 		}
+
+		if b.editingProjection {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, "  "+b.projectionInput.View())
+		}
+		if b.editingTable {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, "  "+b.tableInput.View())
+		}
 	}
 
 	return content
 }
+
+// highlightSelection renders the selected lines among the visible lines in view.
+func (b BodyContainer) highlightSelection(view string) string {
+	if !b.selecting {
+		return view
+	}
+	start, end := b.selectionRange()
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		if n := b.Viewport.YOffset + i; n >= start && n <= end {
+			lines[i] = selectionStyle.Render(ansi.Strip(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
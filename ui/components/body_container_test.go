@@ -0,0 +1,125 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestTruncateForDisplay checks that large bodies are cut on a line or rune boundary
+// and that the omitted byte count is exact.
+func TestTruncateForDisplay(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		limit       int
+		wantShown   string
+		wantOmitted int
+	}{
+		{
+			name:        "Under the limit",
+			data:        "short",
+			limit:       10,
+			wantShown:   "short",
+			wantOmitted: 0,
+		},
+		{
+			name:        "Cut at a line break",
+			data:        "line one\nline two\nline three",
+			limit:       20,
+			wantShown:   "line one\nline two\n",
+			wantOmitted: 10,
+		},
+		{
+			name:        "No nearby line break",
+			data:        strings.Repeat("a", 30),
+			limit:       12,
+			wantShown:   strings.Repeat("a", 12),
+			wantOmitted: 18,
+		},
+		{
+			name:        "Does not split a multi-byte rune",
+			data:        "abcé and more",
+			limit:       4,
+			wantShown:   "abc",
+			wantOmitted: 11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shown, omitted := truncateForDisplay([]byte(tt.data), tt.limit)
+			if string(shown) != tt.wantShown {
+				t.Errorf("shown = %q, want %q", shown, tt.wantShown)
+			}
+			if omitted != tt.wantOmitted {
+				t.Errorf("omitted = %d, want %d", omitted, tt.wantOmitted)
+			}
+			if len(shown)+omitted != len(tt.data) {
+				t.Errorf("shown and omitted bytes do not add up to %d", len(tt.data))
+			}
+		})
+	}
+}
+
+// TestSetBodyTruncatesLargeText checks that a large text body is cut for display but kept
+// whole for copying, and that loading the full body lifts the limit.
+func TestSetBodyTruncatesLargeText(t *testing.T) {
+	body := []byte(strings.Repeat("x", maxDisplayBytes+100))
+
+	b := NewBodyContainer()
+	b.SetBody(body, "text/plain")
+	if b.omittedBytes != 100 {
+		t.Fatalf("omittedBytes = %d, want 100", b.omittedBytes)
+	}
+	if len(b.rawContent) != len(body) {
+		t.Errorf("rawContent has %d bytes, want the full %d", len(b.rawContent), len(body))
+	}
+
+	b.loadFullBody()
+	if b.omittedBytes != 0 {
+		t.Errorf("omittedBytes = %d after loading the full body, want 0", b.omittedBytes)
+	}
+
+	b.SetBody([]byte("small"), "text/plain")
+	if b.showFullBody {
+		t.Error("a new body should reset the display limit")
+	}
+}
+
+// TestSelectedTextJoinsWrappedLines checks that a selection spanning soft-wrapped lines
+// is copied as the original lines.
+func TestSelectedTextJoinsWrappedLines(t *testing.T) {
+	b := NewBodyContainer()
+	b.Active = true
+	b.SetWidth(14) // Lines wrap at 10 characters
+	b.SetHeight(10)
+	b.SetContent("first line\n" + strings.Repeat("a", 15) + "\nlast")
+
+	if _, ok := b.SelectedText(); ok {
+		t.Fatal("no lines should be selected initially")
+	}
+
+	// Select from the top line down to the second half of the wrapped line
+	for range 3 {
+		b.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	}
+	got, ok := b.SelectedText()
+	want := "first line\n" + strings.Repeat("a", 15)
+	if !ok || got != want {
+		t.Errorf("SelectedText() = %q, %v, want %q", got, ok, want)
+	}
+
+	// Selecting upwards starts at the bottom line; a continuation copies just its part
+	b.ClearSelection()
+	b.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	b.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	if got, _ := b.SelectedText(); got != "aaaaa\nlast" {
+		t.Errorf("SelectedText() = %q, want %q", got, "aaaaa\nlast")
+	}
+
+	if !b.ClearSelection() || b.ClearSelection() {
+		t.Error("ClearSelection() should report only an existing selection")
+	}
+}
@@ -0,0 +1,93 @@
+// Package components provides UI components for the LazyPost application.
+package components
+
+import (
+	"encoding/csv"
+	"mime"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tableColumnSeparator is placed between columns of a rendered delimited table.
+const tableColumnSeparator = " │ "
+
+// delimiterForContentType returns the field delimiter for CSV and TSV content types.
+// The boolean is false when the content type is not a delimited text format.
+func delimiterForContentType(contentType string) (rune, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return 0, false
+	}
+
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return ',', true
+	case "text/tab-separated-values", "text/tsv":
+		return '\t', true
+	}
+	return 0, false
+}
+
+// renderDelimitedTable parses CSV/TSV data and lays it out as an aligned table.
+// The first record is treated as the column headers and rendered in bold above a separator line.
+// Rows with fewer fields than the widest row are padded with empty cells.
+func renderDelimitedTable(data string, delimiter rune) (string, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1 // Allow ragged rows
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return "", err
+	}
+	if len(records) == 0 {
+		return "", nil
+	}
+
+	// Measure each column using display width so wide characters stay aligned
+	var widths []int
+	for _, record := range records {
+		for i, field := range record {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], lipgloss.Width(field))
+		}
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true)
+
+	var result strings.Builder
+	for rowIdx, record := range records {
+		cells := make([]string, len(widths))
+		for i := range widths {
+			field := ""
+			if i < len(record) {
+				field = record[i]
+			}
+			cell := field + strings.Repeat(" ", widths[i]-lipgloss.Width(field))
+			if rowIdx == 0 {
+				cell = headerStyle.Render(cell)
+			}
+			cells[i] = cell
+		}
+		result.WriteString(strings.Join(cells, tableColumnSeparator))
+
+		if rowIdx == 0 {
+			// Separator line under the header row
+			separators := make([]string, len(widths))
+			for i, w := range widths {
+				separators[i] = strings.Repeat("─", w)
+			}
+			result.WriteString("\n")
+			result.WriteString(strings.Join(separators, "─┼─"))
+		}
+		if rowIdx < len(records)-1 {
+			result.WriteString("\n")
+		}
+	}
+
+	return result.String(), nil
+}
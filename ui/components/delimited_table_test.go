@@ -0,0 +1,54 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TestDelimiterForContentType checks CSV/TSV detection from Content-Type headers.
+func TestDelimiterForContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		delimiter   rune
+		ok          bool
+	}{
+		{"text/csv", ',', true},
+		{"text/csv; charset=utf-8", ',', true},
+		{"application/csv", ',', true},
+		{"text/tab-separated-values", '\t', true},
+		{"application/json", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		delimiter, ok := delimiterForContentType(tt.contentType)
+		if delimiter != tt.delimiter || ok != tt.ok {
+			t.Errorf("delimiterForContentType(%q) = (%q, %v), expected (%q, %v)", tt.contentType, delimiter, ok, tt.delimiter, tt.ok)
+		}
+	}
+}
+
+// TestRenderDelimitedTable checks that columns are aligned and ragged rows are padded.
+func TestRenderDelimitedTable(t *testing.T) {
+	table, err := renderDelimitedTable("id,name\n1,Alice\n22\n", ',')
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(table, "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines (header, separator, 2 rows), got %d:\n%s", len(lines), table)
+	}
+
+	width := lipgloss.Width(lines[0])
+	for i, line := range lines {
+		if lipgloss.Width(line) != width {
+			t.Errorf("line %d has width %d, expected %d: %q", i, lipgloss.Width(line), width, line)
+		}
+	}
+	if !strings.HasPrefix(lines[3], "22 │ ") {
+		t.Errorf("expected ragged row to be padded, got %q", lines[3])
+	}
+}
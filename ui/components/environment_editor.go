@@ -0,0 +1,440 @@
+// Package components provides UI components for the LazyPost application.
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/env"
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// editorPane identifies which list of the environment editor has focus.
+type editorPane int
+
+const (
+	paneEnvironments editorPane = iota // The list of environments
+	paneVariables                      // The variables of the selected environment
+)
+
+// editorField identifies what the environment editor's input is editing.
+type editorField int
+
+const (
+	editNone          editorField = iota // Not editing
+	editEnvName                          // Name of a new or existing environment
+	editVariableName                     // Name of a new or existing variable
+	editVariableValue                    // Value of a new or existing variable
+)
+
+// EnvironmentEditor is a full-screen editor for environments and their variables.
+// While visible it captures all key presses. Changes are made to a copy of the store,
+// returned by Store after every key so they apply to substitution immediately.
+type EnvironmentEditor struct {
+	Visible bool // Visible indicates whether the editor is shown.
+	Width   int  // Width of the editor in characters.
+	Height  int  // Height of the editor in characters.
+
+	store       env.Store       // store is the environments being edited.
+	pane        editorPane      // pane is the list that has focus.
+	envIndex    int             // envIndex is the selected environment.
+	varIndex    int             // varIndex is the selected variable of the selected environment.
+	editing     editorField     // editing is what the input is editing, editNone when closed.
+	adding      bool            // adding is true when the input creates a new item instead of renaming one.
+	pendingName string          // pendingName is the variable name entered before its value.
+	input       textinput.Model // input edits names and values.
+	status      string          // status reports the last error, e.g. a duplicate name.
+}
+
+// NewEnvironmentEditor creates a hidden environment editor.
+func NewEnvironmentEditor() EnvironmentEditor {
+	input := textinput.New()
+	input.CharLimit = 1024
+	return EnvironmentEditor{input: input}
+}
+
+// Open shows the editor with a copy of store, selecting the active environment.
+func (e *EnvironmentEditor) Open(store env.Store) {
+	e.store = copyStore(store)
+	e.pane = paneEnvironments
+	e.envIndex = 0
+	for i, environment := range e.store.Environments {
+		if environment.Name == e.store.Active {
+			e.envIndex = i
+		}
+	}
+	e.varIndex = 0
+	e.stopEditing()
+	e.status = ""
+	e.Visible = true
+}
+
+// Store returns the environments as edited so far.
+func (e *EnvironmentEditor) Store() env.Store {
+	return copyStore(e.store)
+}
+
+// SetSize sets the size of the editor in characters.
+func (e *EnvironmentEditor) SetSize(width, height int) {
+	e.Width = width
+	e.Height = height
+	e.input.Width = max(width/2, 10)
+}
+
+// Update handles key presses while the editor is visible.
+// It returns true when the editor was closed, after which the store should be saved.
+func (e *EnvironmentEditor) Update(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if e.editing != editNone {
+		return false, e.updateInput(msg)
+	}
+
+	e.status = ""
+	switch msg.String() {
+	case "esc", "q":
+		e.Visible = false
+		return true, nil
+	case "tab", "shift+tab", "left", "right", "h", "l":
+		if e.pane == paneEnvironments && e.current() != nil {
+			e.pane = paneVariables
+		} else {
+			e.pane = paneEnvironments
+		}
+	case "up", "k":
+		e.moveSelection(-1)
+	case "down", "j":
+		e.moveSelection(1)
+	case "shift+up", "K":
+		e.reorder(-1)
+	case "shift+down", "J":
+		e.reorder(1)
+	case "a":
+		return false, e.startAdding()
+	case "enter", "e":
+		return false, e.startEditing()
+	case "d", "delete":
+		e.deleteSelected()
+	case " ":
+		// Select the highlighted environment for substitution
+		if current := e.current(); current != nil {
+			e.store.Active = current.Name
+		}
+	case "s":
+		if v := e.currentVariable(); v != nil && e.pane == paneVariables {
+			v.Secret = !v.Secret
+		}
+	}
+	return false, nil
+}
+
+// updateInput handles key presses while a name or value is being entered.
+func (e *EnvironmentEditor) updateInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		e.stopEditing()
+		return nil
+	case "enter":
+		return e.commitInput()
+	}
+
+	var cmd tea.Cmd
+	e.input, cmd = e.input.Update(msg)
+	return cmd
+}
+
+// startAdding opens the input for a new environment or variable, depending on the focused pane.
+func (e *EnvironmentEditor) startAdding() tea.Cmd {
+	if e.pane == paneVariables && e.current() != nil {
+		return e.startInput(editVariableName, true, "Variable name: ", "", false)
+	}
+	return e.startInput(editEnvName, true, "Environment name: ", "", false)
+}
+
+// startEditing opens the input to edit the selected environment's name or the selected variable.
+func (e *EnvironmentEditor) startEditing() tea.Cmd {
+	if e.pane == paneVariables {
+		if v := e.currentVariable(); v != nil {
+			return e.startInput(editVariableName, false, "Variable name: ", v.Name, false)
+		}
+		return nil
+	}
+	if current := e.current(); current != nil {
+		return e.startInput(editEnvName, false, "Environment name: ", current.Name, false)
+	}
+	return nil
+}
+
+// startInput focuses the input for field, pre-filled with value.
+func (e *EnvironmentEditor) startInput(field editorField, adding bool, prompt, value string, secret bool) tea.Cmd {
+	e.editing = field
+	e.adding = adding
+	e.input.Prompt = prompt
+	e.input.SetValue(value)
+	e.input.CursorEnd()
+	e.input.EchoMode = textinput.EchoNormal
+	if secret {
+		e.input.EchoMode = textinput.EchoPassword
+	}
+	return e.input.Focus()
+}
+
+// stopEditing closes the input without applying it.
+func (e *EnvironmentEditor) stopEditing() {
+	e.editing = editNone
+	e.adding = false
+	e.pendingName = ""
+	e.input.Blur()
+}
+
+// commitInput applies the entered name or value. A variable's name is followed by its value.
+func (e *EnvironmentEditor) commitInput() tea.Cmd {
+	value := strings.TrimSpace(e.input.Value())
+
+	switch e.editing {
+	case editEnvName:
+		if value == "" {
+			e.status = "Environment name cannot be empty"
+			return nil
+		}
+		if existing := e.store.Find(value); existing != nil && (e.adding || existing != e.current()) {
+			e.status = fmt.Sprintf("An environment called %q already exists", value)
+			return nil
+		}
+		if e.adding {
+			e.store.Environments = append(e.store.Environments, env.Environment{Name: value})
+			e.envIndex = len(e.store.Environments) - 1
+			e.varIndex = 0
+			if e.store.Current() == nil {
+				e.store.Active = value // The first environment is selected right away
+			}
+		} else {
+			current := e.current()
+			if e.store.Active == current.Name {
+				e.store.Active = value
+			}
+			current.Name = value
+		}
+		e.stopEditing()
+
+	case editVariableName:
+		if !env.ValidName(value) {
+			e.status = fmt.Sprintf("%q is not a valid variable name (letters, digits, _, - and .)", value)
+			return nil
+		}
+		if existing, ok := e.current().Lookup(value); ok && (e.adding || existing.Name != e.currentVariable().Name) {
+			e.status = fmt.Sprintf("A variable called %q already exists", value)
+			return nil
+		}
+		current, secret := "", false
+		if !e.adding {
+			current, secret = e.currentVariable().Value, e.currentVariable().Secret
+		}
+		e.pendingName = value
+		return e.startInput(editVariableValue, e.adding, value+" = ", current, secret)
+
+	case editVariableValue:
+		// Values keep surrounding spaces; only names are trimmed
+		raw := e.input.Value()
+		environment := e.current()
+		if e.adding {
+			environment.Variables = append(environment.Variables, env.Variable{Name: e.pendingName, Value: raw})
+			e.varIndex = len(environment.Variables) - 1
+		} else {
+			v := e.currentVariable()
+			v.Name = e.pendingName
+			v.Value = raw
+		}
+		e.stopEditing()
+	}
+	return nil
+}
+
+// moveSelection moves the selection in the focused pane by delta, staying in range.
+func (e *EnvironmentEditor) moveSelection(delta int) {
+	if e.pane == paneVariables {
+		if current := e.current(); current != nil {
+			e.varIndex = clampIndex(e.varIndex+delta, len(current.Variables))
+		}
+		return
+	}
+	e.envIndex = clampIndex(e.envIndex+delta, len(e.store.Environments))
+	e.varIndex = 0
+}
+
+// reorder moves the selected environment or variable up (-1) or down (1).
+func (e *EnvironmentEditor) reorder(delta int) {
+	if e.pane == paneVariables {
+		current := e.current()
+		if current == nil {
+			return
+		}
+		to := e.varIndex + delta
+		if to < 0 || to >= len(current.Variables) {
+			return
+		}
+		current.Variables[e.varIndex], current.Variables[to] = current.Variables[to], current.Variables[e.varIndex]
+		e.varIndex = to
+		return
+	}
+	to := e.envIndex + delta
+	if to < 0 || to >= len(e.store.Environments) {
+		return
+	}
+	e.store.Environments[e.envIndex], e.store.Environments[to] = e.store.Environments[to], e.store.Environments[e.envIndex]
+	e.envIndex = to
+}
+
+// deleteSelected removes the selected environment or variable.
+func (e *EnvironmentEditor) deleteSelected() {
+	if e.pane == paneVariables {
+		current := e.current()
+		if current == nil || e.varIndex >= len(current.Variables) {
+			return
+		}
+		current.Variables = append(current.Variables[:e.varIndex], current.Variables[e.varIndex+1:]...)
+		e.varIndex = clampIndex(e.varIndex, len(current.Variables))
+		return
+	}
+
+	current := e.current()
+	if current == nil {
+		return
+	}
+	if e.store.Active == current.Name {
+		e.store.Active = ""
+	}
+	e.store.Environments = append(e.store.Environments[:e.envIndex], e.store.Environments[e.envIndex+1:]...)
+	e.envIndex = clampIndex(e.envIndex, len(e.store.Environments))
+	e.varIndex = 0
+}
+
+// current returns the selected environment, or nil if there are none.
+func (e *EnvironmentEditor) current() *env.Environment {
+	if e.envIndex < 0 || e.envIndex >= len(e.store.Environments) {
+		return nil
+	}
+	return &e.store.Environments[e.envIndex]
+}
+
+// currentVariable returns the selected variable, or nil if there is none.
+func (e *EnvironmentEditor) currentVariable() *env.Variable {
+	current := e.current()
+	if current == nil || e.varIndex < 0 || e.varIndex >= len(current.Variables) {
+		return nil
+	}
+	return &current.Variables[e.varIndex]
+}
+
+// View renders the editor, or an empty string when hidden.
+func (e EnvironmentEditor) View() string {
+	if !e.Visible {
+		return ""
+	}
+
+	listWidth := max(e.Width/4, 16)
+	variablesWidth := max(e.Width-listWidth-8, 20)
+	paneHeight := max(e.Height-8, 3)
+
+	// Environments, with the active one marked
+	var envs strings.Builder
+	if len(e.store.Environments) == 0 {
+		envs.WriteString("No environments.\nPress 'a' to add one.")
+	}
+	for i, environment := range e.store.Environments {
+		marker := "  "
+		if environment.Name == e.store.Active {
+			marker = "● "
+		}
+		envs.WriteString(e.renderItem(marker+environment.Name, i == e.envIndex, e.pane == paneEnvironments))
+		envs.WriteString("\n")
+	}
+
+	// Variables of the selected environment
+	var vars strings.Builder
+	if current := e.current(); current != nil {
+		if len(current.Variables) == 0 {
+			vars.WriteString("No variables.\nPress 'a' to add one.")
+		}
+		for i, v := range current.Variables {
+			line := v.Name + " = " + v.DisplayValue()
+			if v.Secret {
+				line += " (secret)"
+			}
+			vars.WriteString(e.renderItem(line, i == e.varIndex, e.pane == paneVariables))
+			vars.WriteString("\n")
+		}
+	}
+
+	paneStyle := func(focused bool, width int) lipgloss.Style {
+		style := styles.BorderStyle
+		if focused {
+			style = styles.ActiveBorderStyle
+		}
+		return style.Copy().Padding(0, 1).Width(width).Height(paneHeight)
+	}
+	panes := lipgloss.JoinHorizontal(lipgloss.Top,
+		paneStyle(e.pane == paneEnvironments, listWidth).Render(envs.String()),
+		paneStyle(e.pane == paneVariables, variablesWidth).Render(vars.String()),
+	)
+
+	var footer string
+	switch {
+	case e.editing != editNone:
+		footer = e.input.View() + "\n" + styles.DefaultTheme.HelpTextStyle.Render("Enter: apply • Esc: cancel")
+	default:
+		help := "Tab: switch list • ↑/↓: select • Shift+↑/↓: move • a: add • e: edit • d: delete • Space: use environment • s: toggle secret • Esc: save and close"
+		footer = styles.DefaultTheme.HelpTextStyle.Render(help)
+	}
+	if e.status != "" {
+		footer = styles.DefaultTheme.ErrorStyle.Render(e.status) + "\n" + footer
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		styles.TitleStyle.Render("Environments"),
+		"",
+		panes,
+		footer,
+	)
+
+	style := styles.ActiveBorderStyle.Copy().Padding(0, 1)
+	if e.Width > 0 {
+		style = style.Width(e.Width)
+	}
+	return style.Render(content)
+}
+
+// renderItem renders a list line, highlighted when it is selected in the focused list.
+func (e EnvironmentEditor) renderItem(text string, selected, focused bool) string {
+	if selected && focused {
+		return styles.DropdownSelectedItemStyle.Render(text)
+	}
+	if selected {
+		return styles.SelectedItemStyle.Render(text)
+	}
+	return styles.DropdownItemStyle.Render(text)
+}
+
+// clampIndex keeps i within [0, n), or returns 0 when n is 0.
+func clampIndex(i, n int) int {
+	if n == 0 || i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+// copyStore deep-copies store so edits do not alias the caller's slices.
+func copyStore(store env.Store) env.Store {
+	copied := env.Store{Active: store.Active}
+	for _, environment := range store.Environments {
+		copied.Environments = append(copied.Environments, env.Environment{
+			Name:      environment.Name,
+			Variables: append([]env.Variable(nil), environment.Variables...),
+		})
+	}
+	return copied
+}
@@ -0,0 +1,83 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/env"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeKeys sends each key to the editor, typing any multi-character string rune by rune.
+func typeKeys(e *EnvironmentEditor, keys ...string) {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case " ":
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		e.Update(msg)
+	}
+}
+
+// TestEnvironmentEditor checks adding, editing, reordering and deleting environments and variables.
+func TestEnvironmentEditor(t *testing.T) {
+	e := NewEnvironmentEditor()
+	e.Open(env.Store{})
+
+	// The first environment added becomes the active one
+	typeKeys(&e, "a", "dev", "enter")
+	store := e.Store()
+	if store.Active != "dev" || len(store.Environments) != 1 {
+		t.Fatalf("after adding dev: %+v", store)
+	}
+
+	// Add two variables and mark the second one secret
+	typeKeys(&e, "tab", "a", "host", "enter", "localhost", "enter")
+	typeKeys(&e, "a", "token", "enter", "abc", "enter", "s")
+	vars := e.Store().Current().Variables
+	if len(vars) != 2 || vars[1].Name != "token" || !vars[1].Secret {
+		t.Fatalf("variables = %+v", vars)
+	}
+
+	// Invalid and duplicate names are rejected
+	typeKeys(&e, "a", "bad name", "enter")
+	if e.status == "" {
+		t.Error("expected an error for an invalid variable name")
+	}
+	typeKeys(&e, "esc", "a", "host", "enter")
+	if e.status == "" {
+		t.Error("expected an error for a duplicate variable name")
+	}
+	typeKeys(&e, "esc")
+
+	// Move the secret to the top, then delete it
+	typeKeys(&e, "K")
+	if got := e.Store().Current().Variables[0].Name; got != "token" {
+		t.Errorf("first variable after reorder = %q, want token", got)
+	}
+	typeKeys(&e, "d")
+	if vars := e.Store().Current().Variables; len(vars) != 1 || vars[0].Name != "host" {
+		t.Errorf("variables after delete = %+v", vars)
+	}
+
+	// Renaming the active environment keeps it active
+	typeKeys(&e, "tab", "e")
+	e.input.SetValue("local")
+	typeKeys(&e, "enter")
+	if store := e.Store(); store.Active != "local" {
+		t.Errorf("active environment after rename = %q, want local", store.Active)
+	}
+
+	// Closing reports that the store should be saved
+	if closed, _ := e.Update(tea.KeyMsg{Type: tea.KeyEsc}); !closed || e.Visible {
+		t.Error("Esc should close the editor")
+	}
+}
@@ -7,17 +7,20 @@ import (
 	"github.com/atotto/clipboard" // Added for clipboard functionality
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // HeadersContainer represents a component for displaying HTTP response headers.
 // It formats and displays header information. If active, it also shows a hint
 // for copying the content to the clipboard using the 'y' key.
 type HeadersContainer struct {
-	Content    string // Content is the formatted header text to be displayed.
-	rawContent string // rawContent stores the unformatted content for clipboard copying.
-	Width      int    // Width is the width of the component in characters.
-	Height     int    // Height is the height of thecomponent in characters.
-	Active     bool   // Active indicates whether the component is currently focused and can respond to key presses like 'y'.
+	Content       string              // Content is the formatted header text to be displayed.
+	rawContent    string              // rawContent stores the unformatted content for clipboard copying.
+	Width         int                 // Width is the width of the component in characters.
+	Height        int                 // Height is the height of thecomponent in characters.
+	Active        bool                // Active indicates whether the component is currently focused and can respond to key presses like 'y'.
+	copyFilter    func(string) string // copyFilter, if set, transforms text before it is copied (e.g. to redact credentials).
+	displayFilter func(string) string // displayFilter, if set, transforms the plain text before it is shown (e.g. to mask secrets).
 }
 
 // NewHeadersContainer creates and initializes a new HeadersContainer.
@@ -38,6 +41,17 @@ func (h *HeadersContainer) SetContent(content string) {
 	h.rawContent = content // Store raw content
 }
 
+// SetCopyFilter sets a function applied to the text before it is copied to the clipboard.
+func (h *HeadersContainer) SetCopyFilter(filter func(string) string) {
+	h.copyFilter = filter
+}
+
+// SetDisplayFilter sets a function applied to the plain header text before it is shown.
+// The styling is dropped while a filter is set. nil shows the content as is.
+func (h *HeadersContainer) SetDisplayFilter(filter func(string) string) {
+	h.displayFilter = filter
+}
+
 // SetWidth sets the rendering width for the HeadersContainer.
 func (h *HeadersContainer) SetWidth(width int) {
 	h.Width = width
@@ -60,12 +74,15 @@ func (h *HeadersContainer) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if h.Active && msg.String() == "y" {
-			err := clipboard.WriteAll(h.rawContent)
+			// Copy plain text, without the styling used for display
+			text := ansi.Strip(h.rawContent)
+			if h.copyFilter != nil {
+				text = h.copyFilter(text)
+			}
+			err := clipboard.WriteAll(text)
 			if err != nil {
-				// Optionally, send a message back to the app to show a toast
-				fmt.Println("Error copying to clipboard:", err)
+				return ShowToast(fmt.Sprintf("Error copying to clipboard: %v", err))
 			}
-			// Optionally, provide user feedback (e.g., via a toast message)
 			return nil
 		}
 	}
@@ -82,6 +99,9 @@ func (h HeadersContainer) View() string {
 	}
 
 	baseContent := h.Content
+	if h.displayFilter != nil {
+		baseContent = h.displayFilter(ansi.Strip(baseContent))
+	}
 
 	if h.Active {
 		helpStyle := lipgloss.NewStyle().
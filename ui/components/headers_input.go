@@ -2,6 +2,8 @@
 package components
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/RAshkettle/LazyPost/ui/styles"
@@ -29,18 +31,19 @@ type HeaderInput struct {
 // It handles focus navigation between rows and between the header name and value fields within a row.
 // It also provides functionality to retrieve all entered headers as a map.
 type HeadersInputContainer struct {
-	inputs          []HeaderInput  // inputs is the slice of HeaderInput rows.
-	focusedRow      int            // focusedRow is the index of the currently focused row.
-	focusedInput    int            // focusedInput indicates which part of the focused row has focus (0 for HeaderSelect, 1 for ValueInput).
-	Active          bool           // Active indicates if the container itself is focused and interactive.
-	width           int            // width is the total width of the container.
-	height          int            // height is the total height of the container.
-	showHelp        bool           // showHelp determines if the help text is displayed.
-	helpText        string         // helpText is the instructional message displayed to the user.
-	headerLabel     string         // headerLabel is the text label for the header name column.
-	valueLabel      string         // valueLabel is the text label for the header value column.
-	baseHeaderStyle lipgloss.Style // baseHeaderStyle is the base style for the header name input area.
-	baseValueStyle  lipgloss.Style // baseValueStyle is the base style for the header value input area.
+	inputs          []HeaderInput     // inputs is the slice of HeaderInput rows.
+	focusedRow      int               // focusedRow is the index of the currently focused row.
+	focusedInput    int               // focusedInput indicates which part of the focused row has focus (0 for HeaderSelect, 1 for ValueInput).
+	Active          bool              // Active indicates if the container itself is focused and interactive.
+	width           int               // width is the total width of the container.
+	height          int               // height is the total height of the container.
+	showHelp        bool              // showHelp determines if the help text is displayed.
+	helpText        string            // helpText is the instructional message displayed to the user.
+	headerLabel     string            // headerLabel is the text label for the header name column.
+	valueLabel      string            // valueLabel is the text label for the header value column.
+	baseHeaderStyle lipgloss.Style    // baseHeaderStyle is the base style for the header name input area.
+	baseValueStyle  lipgloss.Style    // baseValueStyle is the base style for the header value input area.
+	maskHeader      func(string) bool // maskHeader reports whether the value of the named header is shown as asterisks; nil shows all values.
 }
 
 // headerOptionsStrings provides a default list of common HTTP header names for the dropdown.
@@ -64,6 +67,7 @@ func NewHeadersInputContainer() HeadersInputContainer {
 		valIn.Prompt = "" // Remove the prompt indicator
 		valIn.CharLimit = 256
 		valIn.Width = 40 // Default width, will be adjusted
+		valIn.Validate = ValidateHeaderValue
 
 		inputs[i] = HeaderInput{
 			HeaderSelect:   make([]string, len(headerOptionsStrings)), // Initialize with a copy
@@ -111,7 +115,7 @@ func (h *HeadersInputContainer) SetActive(active bool) {
 
 // Init is the first command that will be run by Bubble Tea for this component.
 // It typically returns textinput.Blink to enable cursor blinking for text inputs.
-func (h HeadersInputContainer) Init() tea.Cmd {
+func (h *HeadersInputContainer) Init() tea.Cmd {
 	return textinput.Blink
 }
 
@@ -142,8 +146,8 @@ func (h *HeadersInputContainer) SetHeight(height int) {
 // Update handles messages for the HeadersInputContainer, primarily key presses.
 // It manages navigation (up/down rows, left/right between fields), opening/closing dropdowns,
 // and delegating character input to the focused ValueInput field.
-// It returns the updated HeadersInputContainer and any command to be executed (e.g., focus, blink).
-func (h *HeadersInputContainer) Update(msg tea.Msg) (HeadersInputContainer, tea.Cmd) {
+// The container is updated in place; it returns any command to be executed (e.g., focus, blink).
+func (h *HeadersInputContainer) Update(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
@@ -162,7 +166,7 @@ func (h *HeadersInputContainer) Update(msg tea.Msg) (HeadersInputContainer, tea.
 		if h.focusedInput == 1 && currentInput.ValueInput.Focused() && !isNavKey && !isEnterKey {
 			currentInput.ValueInput, cmd = currentInput.ValueInput.Update(msg)
 			cmds = append(cmds, cmd)
-			return *h, tea.Batch(cmds...) // Character input handled, return.
+			return tea.Batch(cmds...) // Character input handled, return.
 		}
 
 		// Store previous state for auto-closing dropdown
@@ -232,7 +236,7 @@ func (h *HeadersInputContainer) Update(msg tea.Msg) (HeadersInputContainer, tea.
 	focusCmd := h.focusCurrentInput()
 	cmds = append(cmds, focusCmd)
 
-	return *h, tea.Batch(cmds...)
+	return tea.Batch(cmds...)
 }
 
 // focusCurrentInput ensures that the correct internal input field (HeaderSelect or ValueInput)
@@ -330,13 +334,24 @@ func (h HeadersInputContainer) View() string {
 		} else {
 			valBoxStyle = valBoxStyle.BorderForeground(styles.SecondaryColor) // Or a lipgloss.Color
 		}
-		valueView := valBoxStyle.Width(input.valueInputWidth).Render(input.ValueInput.View())
+		valueInput := input.ValueInput
+		if h.maskHeader != nil && h.maskHeader(input.HeaderSelect[input.SelectedHeader]) {
+			valueInput.EchoMode = textinput.EchoPassword
+		}
+		if inputError(input.ValueInput) != nil {
+			valBoxStyle = valBoxStyle.BorderForeground(styles.ErrorColor)
+		}
+		valueView := valBoxStyle.Width(input.valueInputWidth).Render(valueInput.View())
 		// --- End Value Input Rendering ---
 
 		row := lipgloss.JoinHorizontal(lipgloss.Top, headerView, " ", valueView)
 		rows = append(rows, row)
 	}
 
+	if errs := h.FieldErrors(); len(errs) > 0 {
+		rows = append(rows, renderInputError(errs[0]))
+	}
+
 	if h.showHelp {
 		// Define help style inline, similar to MethodSelector
 		helpStyle := lipgloss.NewStyle().
@@ -349,6 +364,24 @@ func (h HeadersInputContainer) View() string {
 	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
+// FieldErrors returns the validation problems of the header values.
+func (h HeadersInputContainer) FieldErrors() []FieldError {
+	var errs []FieldError
+	for i, input := range h.inputs {
+		if err := inputError(input.ValueInput); err != nil {
+			field := fmt.Sprintf("Headers row %d (%s)", i+1, input.HeaderSelect[input.SelectedHeader])
+			errs = append(errs, FieldError{Field: field, Err: err})
+		}
+	}
+	return errs
+}
+
+// SetMaskedHeaders sets the function deciding which header values are shown as asterisks,
+// e.g. Authorization in privacy mode. nil shows every value.
+func (h *HeadersInputContainer) SetMaskedHeaders(mask func(string) bool) {
+	h.maskHeader = mask
+}
+
 // GetHeaders returns a map of all valid headers entered by the user.
 // A header is considered valid if its name is not "Empty" and its value is not an empty string.
 func (h HeadersInputContainer) GetHeaders() map[string]string {
@@ -367,6 +400,43 @@ func (h HeadersInputContainer) GetHeaders() map[string]string {
 	return headers
 }
 
+// SetHeaders replaces all rows with the given headers, sorted by name.
+// Header names missing from a row's dropdown are added to that row's options, so imported
+// custom headers survive. Headers beyond the number of rows are dropped; the number of rows filled is returned.
+func (h *HeadersInputContainer) SetHeaders(headers map[string]string) int {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i := range h.inputs {
+		h.inputs[i].HeaderSelect = append([]string(nil), headerOptionsStrings...)
+		h.inputs[i].SelectedHeader = 0
+		h.inputs[i].DropdownOpen = false
+		h.inputs[i].ValueInput.SetValue("")
+
+		if i >= len(names) {
+			continue
+		}
+
+		index := -1
+		for idx, option := range h.inputs[i].HeaderSelect {
+			if strings.EqualFold(option, names[i]) {
+				index = idx
+				break
+			}
+		}
+		if index < 0 {
+			h.inputs[i].HeaderSelect = append(h.inputs[i].HeaderSelect, names[i])
+			index = len(h.inputs[i].HeaderSelect) - 1
+		}
+		h.inputs[i].SelectedHeader = index
+		h.inputs[i].ValueInput.SetValue(headers[names[i]])
+	}
+	return min(len(names), len(h.inputs))
+}
+
 // GetSelectedValues returns the currently selected header name and its corresponding value
 // for the currently focused row. This can be useful for context-aware operations.
 func (h HeadersInputContainer) GetSelectedValues() (header string, value string) {
@@ -381,6 +451,34 @@ func (h HeadersInputContainer) GetSelectedValues() (header string, value string)
 	return header, value
 }
 
+// FocusedText returns the value and cursor position of the focused value input, if any.
+// Header names are chosen from a list and never contain placeholders.
+func (h HeadersInputContainer) FocusedText() (string, int, bool) {
+	if !h.Active || h.focusedInput != 1 || h.focusedRow < 0 || h.focusedRow >= len(h.inputs) {
+		return "", 0, false
+	}
+	input := h.inputs[h.focusedRow].ValueInput
+	return input.Value(), input.Position(), true
+}
+
+// InsertText inserts text at the cursor of the focused value input and reports whether there was one.
+func (h *HeadersInputContainer) InsertText(text string) bool {
+	if !h.Active || h.focusedInput != 1 || h.focusedRow < 0 || h.focusedRow >= len(h.inputs) {
+		return false
+	}
+	insertAtCursor(&h.inputs[h.focusedRow].ValueInput, text)
+	return true
+}
+
+// StepFocused steps the number or date at the cursor of the focused value input by delta
+// (see StepValue) and reports whether there was one.
+func (h *HeadersInputContainer) StepFocused(delta int) bool {
+	if !h.Active || h.focusedInput != 1 || h.focusedRow < 0 || h.focusedRow >= len(h.inputs) {
+		return false
+	}
+	return stepInput(&h.inputs[h.focusedRow].ValueInput, delta)
+}
+
 // IsDropdownOpen checks if the header name dropdown for the currently focused row is open.
 func (h HeadersInputContainer) IsDropdownOpen() bool {
 	if h.focusedInput == 0 && h.focusedRow >= 0 && h.focusedRow < len(h.inputs) {
@@ -0,0 +1,150 @@
+package components
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// syntaxClass is the kind of JSON token a byte of a body belongs to.
+type syntaxClass uint8
+
+const (
+	syntaxPlain syntaxClass = iota
+	syntaxKey
+	syntaxString
+	syntaxNumber
+	syntaxLiteral
+	syntaxPunctuation
+	syntaxComment
+)
+
+// isJSONContentType reports whether contentType is JSON, e.g. application/json or application/problem+json.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (strings.HasSuffix(mediaType, "/json") || strings.HasSuffix(mediaType, "+json"))
+}
+
+// classifyJSON returns the syntax class of each byte of text. It is lenient, so cut-off
+// bodies and the "// [n]" index comments of paged arrays are classified too.
+func classifyJSON(text string) []syntaxClass {
+	classes := make([]syntaxClass, len(text))
+	fill := func(start, end int, class syntaxClass) {
+		for i := start; i < end; i++ {
+			classes[i] = class
+		}
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(text) && text[end] != '"' && text[end] != '\n' {
+				if text[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(text))
+
+			// A string followed by a colon is an object key
+			class := syntaxString
+			next := end
+			for next < len(text) && (text[next] == ' ' || text[next] == '\t') {
+				next++
+			}
+			if next < len(text) && text[next] == ':' {
+				class = syntaxKey
+			}
+			fill(i, end, class)
+			i = end
+		case c == '/' && i+1 < len(text) && text[i+1] == '/':
+			end := strings.IndexByte(text[i:], '\n')
+			if end < 0 {
+				end = len(text) - i
+			}
+			fill(i, i+end, syntaxComment)
+			i += end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(text) && strings.IndexByte("0123456789.eE+-", text[end]) >= 0 {
+				end++
+			}
+			fill(i, end, syntaxNumber)
+			i = end
+		case c >= 'a' && c <= 'z':
+			end := i + 1
+			for end < len(text) && text[end] >= 'a' && text[end] <= 'z' {
+				end++
+			}
+			if word := text[i:end]; word == "true" || word == "false" || word == "null" {
+				fill(i, end, syntaxLiteral)
+			}
+			i = end
+		case strings.IndexByte("{}[],:", c) >= 0:
+			classes[i] = syntaxPunctuation
+			i++
+		default:
+			i++
+		}
+	}
+	return classes
+}
+
+// styleFor returns the theme style for a syntax class.
+func styleFor(theme styles.SyntaxTheme, class syntaxClass) lipgloss.Style {
+	switch class {
+	case syntaxKey:
+		return theme.Key
+	case syntaxString:
+		return theme.String
+	case syntaxNumber:
+		return theme.Number
+	case syntaxLiteral:
+		return theme.Literal
+	case syntaxPunctuation:
+		return theme.Punctuation
+	case syntaxComment:
+		return theme.Comment
+	}
+	return lipgloss.NewStyle()
+}
+
+// highlightLines styles lines, the result of wrapping text, using the syntax classes
+// of text. continued tells which lines were split from the previous one, so the byte
+// offsets of each line in text can be followed across the removed line breaks.
+func highlightLines(text string, lines []string, continued []bool, theme styles.SyntaxTheme) []string {
+	classes := classifyJSON(text)
+	styled := make([]string, len(lines))
+	offset := 0
+	for i, line := range lines {
+		if i > 0 && !continued[i] {
+			offset++ // The line break between the previous line and this one
+		}
+		if offset+len(line) > len(text) {
+			styled[i] = line
+			continue
+		}
+
+		var b strings.Builder
+		for start := 0; start < len(line); {
+			class := classes[offset+start]
+			end := start + 1
+			for end < len(line) && classes[offset+end] == class {
+				end++
+			}
+			if class == syntaxPlain {
+				b.WriteString(line[start:end])
+			} else {
+				b.WriteString(styleFor(theme, class).Render(line[start:end]))
+			}
+			start = end
+		}
+		styled[i] = b.String()
+		offset += len(line)
+	}
+	return styled
+}
@@ -0,0 +1,53 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestClassifyJSON tests that keys, values and punctuation are told apart.
+func TestClassifyJSON(t *testing.T) {
+	text := `{"id": -1.5e3, "ok": true, "name": "a \"b\"", "tags": [null]} // [0]`
+	classes := classifyJSON(text)
+
+	tests := []struct {
+		token string
+		want  syntaxClass
+	}{
+		{`"id"`, syntaxKey},
+		{`-1.5e3`, syntaxNumber},
+		{`true`, syntaxLiteral},
+		{`"a \"b\""`, syntaxString},
+		{`null`, syntaxLiteral},
+		{`{`, syntaxPunctuation},
+		{`// [0]`, syntaxComment},
+		{` `, syntaxPlain},
+	}
+	for _, tt := range tests {
+		start := strings.Index(text, tt.token)
+		for i := start; i < start+len(tt.token); i++ {
+			if classes[i] != tt.want {
+				t.Errorf("%q: byte %d has class %d, want %d", tt.token, i-start, classes[i], tt.want)
+				break
+			}
+		}
+	}
+}
+
+// TestHighlightLinesKeepsText tests that highlighting wrapped lines only adds styling.
+func TestHighlightLinesKeepsText(t *testing.T) {
+	text := "{\n  \"message\": \"a long value that wraps\",\n  \"count\": 12345\n}"
+	lines, continued := wrapLines(text, 10)
+
+	for _, theme := range styles.SyntaxThemes {
+		styled := highlightLines(text, lines, continued, theme)
+		for i := range lines {
+			if got := ansi.Strip(styled[i]); got != lines[i] {
+				t.Errorf("theme %s, line %d: got %q, want %q", theme.Name, i, got, lines[i])
+			}
+		}
+	}
+}
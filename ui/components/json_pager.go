@@ -0,0 +1,96 @@
+// Package components provides UI components for the LazyPost application.
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jsonPageSize is the number of array elements rendered per page.
+const jsonPageSize = 100
+
+// jsonArrayPager splits a large top-level JSON array into pages of jsonPageSize elements,
+// so only one page at a time has to be formatted and laid out in the viewport.
+type jsonArrayPager struct {
+	elements []json.RawMessage // elements holds the undecoded array elements.
+	page     int               // page is the zero-based index of the current page.
+}
+
+// newJSONArrayPager returns a pager for body if it is a JSON array with more than
+// jsonPageSize elements. The boolean is false for any other body.
+func newJSONArrayPager(body []byte) (*jsonArrayPager, bool) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return nil, false
+	}
+
+	var elements []json.RawMessage
+	if err := json.Unmarshal(trimmed, &elements); err != nil {
+		return nil, false
+	}
+	if len(elements) <= jsonPageSize {
+		return nil, false
+	}
+	return &jsonArrayPager{elements: elements}, true
+}
+
+// pageCount returns the total number of pages.
+func (p *jsonArrayPager) pageCount() int {
+	return (len(p.elements) + jsonPageSize - 1) / jsonPageSize
+}
+
+// bounds returns the start (inclusive) and end (exclusive) element indexes of the current page.
+func (p *jsonArrayPager) bounds() (int, int) {
+	start := p.page * jsonPageSize
+	end := min(start+jsonPageSize, len(p.elements))
+	return start, end
+}
+
+// next moves to the next page. It reports whether the page changed.
+func (p *jsonArrayPager) next() bool {
+	if p.page >= p.pageCount()-1 {
+		return false
+	}
+	p.page++
+	return true
+}
+
+// prev moves to the previous page. It reports whether the page changed.
+func (p *jsonArrayPager) prev() bool {
+	if p.page == 0 {
+		return false
+	}
+	p.page--
+	return true
+}
+
+// render formats the elements of the current page as an indented JSON array slice.
+// Each element is preceded by a comment line with its index in the full array.
+func (p *jsonArrayPager) render() string {
+	start, end := p.bounds()
+
+	var result strings.Builder
+	result.WriteString("[\n")
+	for i := start; i < end; i++ {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, p.elements[i], "  ", "  "); err != nil {
+			indented.Reset()
+			indented.Write(p.elements[i])
+		}
+		fmt.Fprintf(&result, "  // [%d]\n  %s", i, indented.String())
+		if i < len(p.elements)-1 {
+			result.WriteString(",")
+		}
+		result.WriteString("\n")
+	}
+	result.WriteString("]")
+	return result.String()
+}
+
+// indicator describes the visible element range, e.g. "Elements 101-200 of 5432 (page 2/55)".
+func (p *jsonArrayPager) indicator() string {
+	start, end := p.bounds()
+	return fmt.Sprintf("Elements %d-%d of %d (page %d/%d)", start+1, end, len(p.elements), p.page+1, p.pageCount())
+}
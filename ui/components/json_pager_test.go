@@ -0,0 +1,136 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// jsonArray returns a JSON array of n objects with ids 0 to n-1.
+func jsonArray(n int) []byte {
+	elements := make([]string, n)
+	for i := range elements {
+		elements[i] = fmt.Sprintf(`{"id":%d}`, i)
+	}
+	return []byte("[" + strings.Join(elements, ",") + "]")
+}
+
+// TestNewJSONArrayPager checks that only JSON arrays longer than a page are paged.
+func TestNewJSONArrayPager(t *testing.T) {
+	tests := []struct {
+		name string
+		body []byte
+		want bool
+	}{
+		{"array longer than a page", jsonArray(jsonPageSize + 1), true},
+		{"array filling exactly one page", jsonArray(jsonPageSize), false},
+		{"short array", jsonArray(3), false},
+		{"leading whitespace", append([]byte("\n  "), jsonArray(jsonPageSize+1)...), true},
+		{"object", []byte(`{"items": []}`), false},
+		{"invalid JSON", []byte(`[1, 2`), false},
+		{"empty body", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := newJSONArrayPager(tt.body); got != tt.want {
+				t.Errorf("newJSONArrayPager() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestJSONArrayPagerNavigation checks the page count, the bounds of the last partial page
+// and that next and prev stop at the edges.
+func TestJSONArrayPagerNavigation(t *testing.T) {
+	pager, ok := newJSONArrayPager(jsonArray(2*jsonPageSize + 50))
+	if !ok {
+		t.Fatal("newJSONArrayPager() = false, want a pager")
+	}
+	if got := pager.pageCount(); got != 3 {
+		t.Errorf("pageCount() = %d, want 3", got)
+	}
+
+	if pager.prev() {
+		t.Error("prev() on the first page = true, want false")
+	}
+	if !pager.next() || !pager.next() {
+		t.Fatal("next() should move to the last page")
+	}
+	if start, end := pager.bounds(); start != 2*jsonPageSize || end != 2*jsonPageSize+50 {
+		t.Errorf("bounds() on the last page = %d, %d, want %d, %d", start, end, 2*jsonPageSize, 2*jsonPageSize+50)
+	}
+	if pager.next() {
+		t.Error("next() on the last page = true, want false")
+	}
+	if got, want := pager.indicator(), "Elements 201-250 of 250 (page 3/3)"; got != want {
+		t.Errorf("indicator() = %q, want %q", got, want)
+	}
+
+	if !pager.prev() {
+		t.Error("prev() on the last page = false, want true")
+	}
+	if got, want := pager.indicator(), "Elements 101-200 of 250 (page 2/3)"; got != want {
+		t.Errorf("indicator() = %q, want %q", got, want)
+	}
+}
+
+// TestJSONArrayPagerRender checks that a page is rendered with the index of each element
+// in the full array, and without a trailing comma after the last element.
+func TestJSONArrayPagerRender(t *testing.T) {
+	pager, _ := newJSONArrayPager(jsonArray(jsonPageSize + 2))
+
+	first := pager.render()
+	if !strings.HasPrefix(first, "[\n  // [0]\n  {\n    \"id\": 0\n  },\n") {
+		t.Errorf("first page starts with:\n%s", first[:min(len(first), 80)])
+	}
+	if !strings.Contains(first, "// [99]") || strings.Contains(first, "// [100]") {
+		t.Error("first page should hold elements 0 to 99")
+	}
+	if !strings.HasSuffix(first, "},\n]") {
+		t.Errorf("first page should end after a comma, since more elements follow:\n%s", first[len(first)-20:])
+	}
+
+	pager.next()
+	last := pager.render()
+	want := "[\n  // [100]\n  {\n    \"id\": 100\n  },\n  // [101]\n  {\n    \"id\": 101\n  }\n]"
+	if last != want {
+		t.Errorf("last page =\n%s\nwant\n%s", last, want)
+	}
+
+	// Stripped of the index comments, the page is valid JSON
+	var lines []string
+	for _, line := range strings.Split(last, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "//") {
+			lines = append(lines, line)
+		}
+	}
+	var elements []map[string]int
+	if err := json.Unmarshal([]byte(strings.Join(lines, "\n")), &elements); err != nil || len(elements) != 2 {
+		t.Errorf("last page without comments is not a JSON array of 2 elements: %v", err)
+	}
+}
+
+// TestBodyContainerPagesLargeArrays checks that the Body view shows the page indicator of a
+// large JSON array and that 'n' and 'p' move between pages.
+func TestBodyContainerPagesLargeArrays(t *testing.T) {
+	b := NewBodyContainer()
+	b.Active = true
+	b.SetWidth(120)
+	b.SetHeight(20)
+	b.SetBody(jsonArray(jsonPageSize+2), "application/json")
+
+	if view := b.View(); !strings.Contains(view, "Elements 1-100 of 102 (page 1/2)") {
+		t.Errorf("view does not show the first page indicator:\n%s", view)
+	}
+	b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if view := b.View(); !strings.Contains(view, "Elements 101-102 of 102 (page 2/2)") || !strings.Contains(view, "// [100]") {
+		t.Errorf("'n' did not show the last page:\n%s", view)
+	}
+	b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	if view := b.View(); !strings.Contains(view, "(page 1/2)") {
+		t.Errorf("'p' did not go back to the first page:\n%s", view)
+	}
+}
@@ -0,0 +1,119 @@
+// Package components provides UI components for the LazyPost application.
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// parseProjection splits a comma-separated list of dotted key paths (e.g. "id, user.name")
+// into path segments. Empty entries are ignored.
+func parseProjection(spec string) [][]string {
+	var paths [][]string
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		paths = append(paths, strings.Split(entry, "."))
+	}
+	return paths
+}
+
+// projectJSON keeps only the given key paths of a JSON object, or of every object in a JSON array.
+// Each projected object is flattened to the requested paths, in the order given, with null for
+// missing keys so that every element has the same shape. Non-object array elements are kept as-is.
+func projectJSON(body []byte, paths [][]string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber() // Keep numbers exactly as the server sent them
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case []any:
+		var result bytes.Buffer
+		result.WriteByte('[')
+		for i, element := range v {
+			if i > 0 {
+				result.WriteByte(',')
+			}
+			if err := writeProjectedValue(&result, element, paths); err != nil {
+				return nil, err
+			}
+		}
+		result.WriteByte(']')
+		return result.Bytes(), nil
+	case map[string]any:
+		var result bytes.Buffer
+		if err := writeProjectedValue(&result, v, paths); err != nil {
+			return nil, err
+		}
+		return result.Bytes(), nil
+	}
+	return nil, errors.New("response is not a JSON object or array")
+}
+
+// writeProjectedValue writes value to buf, projecting it to paths if it is an object.
+// Keys are written in path order, which a Go map would not preserve.
+func writeProjectedValue(buf *bytes.Buffer, value any, paths [][]string) error {
+	object, ok := value.(map[string]any)
+	if !ok {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+		return nil
+	}
+
+	buf.WriteByte('{')
+	for i, path := range paths {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(strings.Join(path, "."))
+		if err != nil {
+			return err
+		}
+		field, err := json.Marshal(lookupPath(object, path))
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(field)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// lookupPath follows path through nested objects and returns the value found, or nil if
+// any segment is missing or traverses a non-object.
+func lookupPath(object map[string]any, path []string) any {
+	var current any = object
+	for _, segment := range path {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current, ok = m[segment]
+		if !ok {
+			return nil
+		}
+	}
+	return current
+}
+
+// indentJSON pretty-prints JSON data, returning it unchanged if it cannot be parsed.
+func indentJSON(data []byte) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return string(data)
+	}
+	return indented.String()
+}
@@ -0,0 +1,77 @@
+package components
+
+import (
+	"testing"
+)
+
+// TestProjectJSON checks projection of arrays and objects, including nested and missing paths.
+func TestProjectJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		spec     string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Array of objects",
+			body:     `[{"id":1,"name":"a","extra":true},{"id":2,"name":"b"}]`,
+			spec:     "name, id",
+			expected: `[{"name":"a","id":1},{"name":"b","id":2}]`,
+		},
+		{
+			name:     "Nested path and missing key",
+			body:     `[{"user":{"name":"a"}},{"other":1}]`,
+			spec:     "user.name",
+			expected: `[{"user.name":"a"},{"user.name":null}]`,
+		},
+		{
+			name:     "Single object",
+			body:     `{"id":10,"name":"x"}`,
+			spec:     "id",
+			expected: `{"id":10}`,
+		},
+		{
+			name:     "Non-object elements kept",
+			body:     `[1,{"id":2}]`,
+			spec:     "id",
+			expected: `[1,{"id":2}]`,
+		},
+		{
+			name:     "Large numbers preserved",
+			body:     `[{"id":12345678901234567890}]`,
+			spec:     "id",
+			expected: `[{"id":12345678901234567890}]`,
+		},
+		{
+			name:    "Scalar body",
+			body:    `"text"`,
+			spec:    "id",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid JSON",
+			body:    `not json`,
+			spec:    "id",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := projectJSON([]byte(tt.body), parseProjection(tt.spec))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %s", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("projectJSON() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
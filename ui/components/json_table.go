@@ -0,0 +1,237 @@
+package components
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is one step of a JSONPath: an object key, an array index or a wildcard.
+type pathSegment struct {
+	key      string // Object key, used when index < 0 and wildcard is false
+	index    int    // Array index, -1 when the segment is a key or wildcard
+	wildcard bool   // Whether the segment selects every element of an array or value of an object
+}
+
+// parseJSONPath parses the JSONPath subset used for table columns: "$" followed by
+// ".key", "['key']", "[n]" and "[*]" or ".*". The leading "$" may be omitted, in which
+// case the path is relative, e.g. "user.name".
+func parseJSONPath(path string) ([]pathSegment, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		rest = "." + rest
+	}
+
+	var segments []pathSegment
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name := rest[1 : end+1]
+			if name == "" {
+				return nil, fmt.Errorf("empty key in %q", path)
+			}
+			if name == "*" {
+				segments = append(segments, pathSegment{index: -1, wildcard: true})
+			} else {
+				segments = append(segments, pathSegment{key: name, index: -1})
+			}
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ] in %q", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			switch {
+			case inner == "*":
+				segments = append(segments, pathSegment{index: -1, wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				segments = append(segments, pathSegment{key: inner[1 : len(inner)-1], index: -1})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("invalid index [%s] in %q", inner, path)
+				}
+				segments = append(segments, pathSegment{index: n})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", rest[0], path)
+		}
+	}
+	return segments, nil
+}
+
+// evaluateJSONPath returns the values segments select from value, in document order.
+func evaluateJSONPath(value any, segments []pathSegment) []any {
+	nodes := []any{value}
+	for _, segment := range segments {
+		var next []any
+		for _, node := range nodes {
+			switch v := node.(type) {
+			case []any:
+				if segment.wildcard {
+					next = append(next, v...)
+				} else if segment.index >= 0 && segment.index < len(v) {
+					next = append(next, v[segment.index])
+				}
+			case map[string]any:
+				if segment.wildcard {
+					for _, element := range orderedValues(v) {
+						next = append(next, element)
+					}
+				} else if element, ok := v[segment.key]; ok && segment.index < 0 {
+					next = append(next, element)
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// orderedValues returns the values of an object sorted by key, since a decoded map has no order.
+func orderedValues(object map[string]any) []any {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		values[i] = object[key]
+	}
+	return values
+}
+
+// tableColumn is a column of an extracted table.
+type tableColumn struct {
+	name     string        // Header of the column, the path relative to a row
+	segments []pathSegment // Path of the cell relative to a row
+}
+
+// parseTableSpec splits a comma-separated list of JSONPaths into the path selecting the rows
+// and the columns relative to each row. Absolute paths must share the same rows, i.e. the
+// same prefix up to and including their last "[*]", e.g. "$.items[*].id, $.items[*].user.name";
+// without a "[*]" the document itself is the only row. Relative paths such as "id, user.name"
+// select from the same rows, which are the elements of a top-level array when no absolute
+// path is given.
+func parseTableSpec(spec string) (string, []pathSegment, []tableColumn, error) {
+	rowsPath := ""
+	var rows []pathSegment
+	var columns []tableColumn
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		relative := entry
+		if strings.HasPrefix(entry, "$") {
+			prefix := "$"
+			if i := strings.LastIndex(entry, "[*]"); i >= 0 {
+				prefix, relative = entry[:i+3], entry[i+3:]
+			} else {
+				relative = strings.TrimPrefix(entry, "$")
+			}
+			segments, err := parseJSONPath(prefix)
+			if err != nil {
+				return "", nil, nil, err
+			}
+			if rowsPath != "" && !slices.Equal(segments, rows) {
+				return "", nil, nil, fmt.Errorf("columns select from different arrays: %s and %s", rowsPath, prefix)
+			}
+			rowsPath, rows = prefix, segments
+		}
+
+		segments, err := parseJSONPath(relative)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		name := strings.TrimPrefix(relative, ".")
+		if name == "" {
+			name = "value"
+		}
+		columns = append(columns, tableColumn{name: name, segments: segments})
+	}
+	if len(columns) == 0 {
+		return "", nil, nil, errors.New("no columns given")
+	}
+	if rowsPath == "" {
+		rowsPath, rows = "$[*]", []pathSegment{{index: -1, wildcard: true}}
+	}
+	return rowsPath, rows, columns, nil
+}
+
+// extractTable selects rows and columns from a JSON body as described by spec (see
+// parseTableSpec) and writes them as delimited text with a header row, e.g. CSV with ','
+// or TSV with '\t'. Missing values are empty cells; objects and arrays are written as JSON.
+// It also returns the number of rows.
+func extractTable(body []byte, spec string, delimiter rune) (string, int, error) {
+	rowsPath, rowSegments, columns, err := parseTableSpec(spec)
+	if err != nil {
+		return "", 0, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber() // Keep numbers exactly as the server sent them
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return "", 0, err
+	}
+	rows := evaluateJSONPath(value, rowSegments)
+	if len(rows) == 0 {
+		return "", 0, fmt.Errorf("%s selects no rows", rowsPath)
+	}
+
+	var table strings.Builder
+	writer := csv.NewWriter(&table)
+	writer.Comma = delimiter
+
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.name
+	}
+	writer.Write(record)
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = formatCell(evaluateJSONPath(row, column.segments))
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	return table.String(), len(rows), writer.Error()
+}
+
+// formatCell writes the values a column selected from a row as the text of one cell.
+func formatCell(values []any) string {
+	switch len(values) {
+	case 0:
+		return ""
+	case 1:
+		switch v := values[0].(type) {
+		case nil:
+			return ""
+		case string:
+			return v
+		case json.Number:
+			return v.String()
+		case bool:
+			return strconv.FormatBool(v)
+		}
+		encoded, _ := json.Marshal(values[0])
+		return string(encoded)
+	}
+	encoded, _ := json.Marshal(values)
+	return string(encoded)
+}
@@ -0,0 +1,78 @@
+package components
+
+import (
+	"testing"
+)
+
+// TestExtractTable checks extraction of columns from top-level and nested arrays as CSV and TSV.
+func TestExtractTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		spec      string
+		delimiter rune
+		expected  string
+		wantErr   bool
+	}{
+		{
+			name:      "Relative paths over a top-level array",
+			body:      `[{"id":1,"user":{"name":"Ann, B"}},{"id":2.50,"user":{}}]`,
+			spec:      "id, user.name",
+			delimiter: ',',
+			expected:  "id,user.name\n1,\"Ann, B\"\n2.50,\n",
+		},
+		{
+			name:      "JSONPath into a nested array as TSV",
+			body:      `{"data":{"items":[{"id":"a","tags":["x","y"]},{"id":"b","ok":true}]}}`,
+			spec:      "$.data.items[*].id, $['data']['items'][*].ok, $.data.items[*].tags",
+			delimiter: '\t',
+			expected:  "id\tok\ttags\na\t\t\"[\"\"x\"\",\"\"y\"\"]\"\nb\ttrue\t\n",
+		},
+		{
+			name:      "Wildcard in a column",
+			body:      `[{"roles":[{"name":"admin"},{"name":"dev"}]}]`,
+			spec:      "roles[*].name, roles[0].name",
+			delimiter: ',',
+			expected:  "roles[*].name,roles[0].name\n\"[\"\"admin\"\",\"\"dev\"\"]\",admin\n",
+		},
+		{
+			name:      "Columns from different arrays",
+			body:      `{"a":[],"b":[]}`,
+			spec:      "$.a[*].id, $.b[*].id",
+			delimiter: ',',
+			wantErr:   true,
+		},
+		{
+			name:      "No rows selected",
+			body:      `{"items":[]}`,
+			spec:      "$.items[*].id",
+			delimiter: ',',
+			wantErr:   true,
+		},
+		{
+			name:      "Invalid path",
+			body:      `[]`,
+			spec:      "$.items[x]",
+			delimiter: ',',
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, _, err := extractTable([]byte(tt.body), tt.spec, tt.delimiter)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("extractTable() = %q, want an error", table)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("extractTable() error = %v", err)
+			}
+			if table != tt.expected {
+				t.Errorf("extractTable() = %q, want %q", table, tt.expected)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@
 package components
 
 import (
+	"strings"
+
 	"github.com/RAshkettle/LazyPost/ui/styles"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -51,6 +53,18 @@ func (m *MethodSelector) GetSelectedMethod() string {
 	return m.Methods[m.SelectedMethod]
 }
 
+// SetSelectedMethod selects the given HTTP method (case-insensitive).
+// It returns false and leaves the selection unchanged if the method is not in the list.
+func (m *MethodSelector) SetSelectedMethod(method string) bool {
+	for i, candidate := range m.Methods {
+		if strings.EqualFold(candidate, method) {
+			m.SelectedMethod = i
+			return true
+		}
+	}
+	return false
+}
+
 // Next selects the next HTTP method in the list, wrapping around to the beginning if necessary.
 func (m *MethodSelector) Next() {
 	m.SelectedMethod = (m.SelectedMethod + 1) % len(m.Methods)
@@ -2,28 +2,125 @@
 package components
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/RAshkettle/LazyPost/oauth2"
 	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
-// OAuth2AuthDetailsComponent is a placeholder for OAuth2 authentication details UI.
-// It currently displays a simple message and will be implemented with actual
-// input fields and logic for OAuth2 flows in the future.
+const (
+	oauth2DeviceURLField   = 0 // oauth2DeviceURLField is the index of the device authorization URL input.
+	oauth2TokenURLField    = 1 // oauth2TokenURLField is the index of the token URL input.
+	oauth2ClientIDField    = 2 // oauth2ClientIDField is the index of the client ID input.
+	oauth2ScopeField       = 3 // oauth2ScopeField is the index of the scope input.
+	oauth2AccessTokenField = 4 // oauth2AccessTokenField is the index of the access token input.
+)
+
+// oauth2FlowStep identifies the stage of the device flow an OAuth2FlowMsg reports.
+type oauth2FlowStep int
+
+const (
+	oauth2StepDeviceCode oauth2FlowStep = iota // The device authorization endpoint answered
+	oauth2StepPoll                             // The polling interval elapsed
+	oauth2StepToken                            // The token endpoint answered a poll
+)
+
+// OAuth2FlowMsg reports progress of an OAuth2 device authorization flow.
+// The App routes it to the OAuth2 panel via AuthContainer.UpdateOAuth2Flow,
+// so polling continues while the panel is not focused.
+type OAuth2FlowMsg struct {
+	flowID int               // flowID ties the message to the flow that started it; stale flows are ignored.
+	step   oauth2FlowStep    // step is the stage being reported.
+	code   oauth2.DeviceCode // code is set for oauth2StepDeviceCode.
+	token  oauth2.Token      // token is set for a successful oauth2StepToken.
+	err    error             // err is any error from the endpoint.
+}
+
+// OAuth2AuthDetailsComponent holds the UI for OAuth2 authentication using the device
+// authorization grant. The user enters the server endpoints and client ID and starts the flow
+// with Ctrl+G; the panel then shows the code to enter on the verification page and polls for
+// the token, which is stored in the access token field and sent as a Bearer token.
 type OAuth2AuthDetailsComponent struct {
 	width  int  // width is the width of the component.
 	height int  // height is the height of the component.
 	active bool // active indicates whether the component is currently focused.
+
+	inputs       []textinput.Model // inputs are the endpoint, client, scope and access token fields, indexed by the oauth2*Field constants.
+	focusedField int               // focusedField is the index of the focused input.
+
+	flowID    int               // flowID identifies the current flow, incremented on every start.
+	polling   bool              // polling indicates whether the flow is waiting for the user to authorize.
+	code      oauth2.DeviceCode // code is the device code of the current flow.
+	interval  time.Duration     // interval is the delay between token polls.
+	expiresAt time.Time         // expiresAt is when the device code stops being valid.
+	status    string            // status describes the progress of the flow.
 }
 
 // NewOAuth2AuthDetailsComponent creates a new instance of OAuth2AuthDetailsComponent.
 func NewOAuth2AuthDetailsComponent() OAuth2AuthDetailsComponent {
-	return OAuth2AuthDetailsComponent{}
+	newInput := func(prompt, placeholder string) textinput.Model {
+		ti := textinput.New()
+		ti.Prompt = prompt
+		ti.Placeholder = placeholder
+		ti.Width = 40
+		return ti
+	}
+
+	accessToken := newInput("Access token: ", "Obtained with Ctrl+G, or paste one")
+	accessToken.EchoMode = textinput.EchoPassword
+	accessToken.EchoCharacter = '*'
+
+	deviceURL := newInput("Device URL:   ", "https://auth.example.com/oauth/device/code")
+	deviceURL.Validate = ValidateEndpointURL
+	tokenURL := newInput("Token URL:    ", "https://auth.example.com/oauth/token")
+	tokenURL.Validate = ValidateEndpointURL
+
+	return OAuth2AuthDetailsComponent{
+		inputs: []textinput.Model{
+			deviceURL,
+			tokenURL,
+			newInput("Client ID:    ", "Client identifier"),
+			newInput("Scope:        ", "Optional, space separated"),
+			accessToken,
+		},
+	}
 }
 
-// SetActive sets the active state of the component.
-func (c *OAuth2AuthDetailsComponent) SetActive(active bool) { c.active = active }
+// FieldErrors returns the validation problems of the OAuth2 fields.
+func (c OAuth2AuthDetailsComponent) FieldErrors() []FieldError {
+	var errs []FieldError
+	for _, input := range c.inputs {
+		if err := inputError(input); err != nil {
+			errs = append(errs, FieldError{Field: "OAuth2 " + strings.TrimSuffix(strings.TrimSpace(input.Prompt), ":"), Err: err})
+		}
+	}
+	return errs
+}
+
+// SetMasked sets whether the access token is shown as asterisks.
+func (c *OAuth2AuthDetailsComponent) SetMasked(masked bool) {
+	c.inputs[oauth2AccessTokenField].EchoMode = echoMode(masked)
+}
+
+// SetActive sets the active state of the component, focusing the selected field when active.
+func (c *OAuth2AuthDetailsComponent) SetActive(active bool) {
+	c.active = active
+	for i := range c.inputs {
+		if active && i == c.focusedField {
+			c.inputs[i].Focus()
+		} else {
+			c.inputs[i].Blur()
+		}
+	}
+}
 
 // SetSize sets the dimensions for the component's rendering area.
 func (c *OAuth2AuthDetailsComponent) SetSize(width, height int) {
@@ -31,20 +128,196 @@ func (c *OAuth2AuthDetailsComponent) SetSize(width, height int) {
 	c.height = height
 }
 
-// Update handles messages and updates the component's state.
-// Currently, it's a no-op as the component is a placeholder.
-func (c OAuth2AuthDetailsComponent) Update(msg tea.Msg) tea.Cmd { return nil }
+// Update handles key presses while the component is active.
+// Up/Down move between fields and Ctrl+G starts (or restarts) the device flow.
+func (c *OAuth2AuthDetailsComponent) Update(msg tea.Msg) tea.Cmd {
+	if !c.active {
+		return nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "down":
+			return c.focusField((c.focusedField + 1) % len(c.inputs))
+		case "up":
+			return c.focusField((c.focusedField - 1 + len(c.inputs)) % len(c.inputs))
+		case "ctrl+g":
+			return c.startFlow()
+		}
+	}
+
+	var cmd tea.Cmd
+	c.inputs[c.focusedField], cmd = c.inputs[c.focusedField].Update(msg)
+	return cmd
+}
+
+// focusField moves the focus to the input at index.
+func (c *OAuth2AuthDetailsComponent) focusField(index int) tea.Cmd {
+	c.inputs[c.focusedField].Blur()
+	c.focusedField = index
+	return c.inputs[c.focusedField].Focus()
+}
+
+// deviceConfig returns the flow configuration entered in the fields.
+func (c *OAuth2AuthDetailsComponent) deviceConfig() oauth2.DeviceConfig {
+	return oauth2.DeviceConfig{
+		DeviceAuthURL: strings.TrimSpace(c.inputs[oauth2DeviceURLField].Value()),
+		TokenURL:      strings.TrimSpace(c.inputs[oauth2TokenURLField].Value()),
+		ClientID:      strings.TrimSpace(c.inputs[oauth2ClientIDField].Value()),
+		Scope:         strings.TrimSpace(c.inputs[oauth2ScopeField].Value()),
+	}
+}
+
+// startFlow requests a new device code, abandoning any flow in progress.
+func (c *OAuth2AuthDetailsComponent) startFlow() tea.Cmd {
+	cfg := c.deviceConfig()
+	if cfg.DeviceAuthURL == "" || cfg.TokenURL == "" || cfg.ClientID == "" {
+		c.status = "Device URL, token URL and client ID are required."
+		return nil
+	}
 
-// View renders the OAuth2AuthDetailsComponent.
-// It displays a placeholder message within a styled border.
+	c.flowID++
+	c.polling = false
+	c.code = oauth2.DeviceCode{}
+	c.status = "Requesting device code..."
+
+	flowID := c.flowID
+	return RunJob("OAuth2 device code request", func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		code, err := oauth2.RequestDeviceCode(ctx, http.DefaultClient, cfg)
+		return OAuth2FlowMsg{flowID: flowID, step: oauth2StepDeviceCode, code: code, err: err}, nil
+	})
+}
+
+// schedulePoll waits for the polling interval before asking for the token again.
+func (c *OAuth2AuthDetailsComponent) schedulePoll() tea.Cmd {
+	flowID := c.flowID
+	return tea.Tick(c.interval, func(time.Time) tea.Msg {
+		return OAuth2FlowMsg{flowID: flowID, step: oauth2StepPoll}
+	})
+}
+
+// HandleFlowMsg advances the device flow. Messages from an abandoned flow are ignored.
+func (c *OAuth2AuthDetailsComponent) HandleFlowMsg(msg OAuth2FlowMsg) tea.Cmd {
+	if msg.flowID != c.flowID {
+		return nil
+	}
+
+	switch msg.step {
+	case oauth2StepDeviceCode:
+		if msg.err != nil {
+			c.status = "Error: " + msg.err.Error()
+			return nil
+		}
+		c.code = msg.code
+		c.polling = true
+		c.interval = time.Duration(msg.code.Interval) * time.Second
+		c.expiresAt = time.Now().Add(time.Duration(msg.code.ExpiresIn) * time.Second)
+		if msg.code.ExpiresIn <= 0 {
+			c.expiresAt = time.Now().Add(15 * time.Minute) // No expiry given, stop polling eventually
+		}
+		c.status = "Waiting for authorization..."
+		return c.schedulePoll()
+
+	case oauth2StepPoll:
+		if !c.polling {
+			return nil
+		}
+		if time.Now().After(c.expiresAt) {
+			c.polling = false
+			c.status = "The code expired. Press Ctrl+G to start again."
+			return nil
+		}
+		cfg, flowID, deviceCode := c.deviceConfig(), c.flowID, c.code.DeviceCode
+		return RunJob("OAuth2 token request", func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+			ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			defer cancel()
+			token, err := oauth2.PollToken(ctx, http.DefaultClient, cfg, deviceCode)
+			return OAuth2FlowMsg{flowID: flowID, step: oauth2StepToken, token: token, err: err}, nil
+		})
+
+	case oauth2StepToken:
+		switch {
+		case errors.Is(msg.err, oauth2.ErrAuthorizationPending):
+			return c.schedulePoll()
+		case errors.Is(msg.err, oauth2.ErrSlowDown):
+			c.interval += 5 * time.Second // Required by RFC 8628 section 3.5
+			return c.schedulePoll()
+		case msg.err != nil:
+			c.polling = false
+			c.status = "Error: " + msg.err.Error()
+			return nil
+		}
+
+		c.polling = false
+		c.code = oauth2.DeviceCode{}
+		c.inputs[oauth2AccessTokenField].SetValue(msg.token.AccessToken)
+		c.status = "Token received."
+		if msg.token.ExpiresIn > 0 {
+			c.status = fmt.Sprintf("Token received, expires at %s.",
+				time.Now().Add(time.Duration(msg.token.ExpiresIn)*time.Second).Format("15:04:05"))
+		}
+		return ShowToast("OAuth2 token received")
+	}
+	return nil
+}
+
+// GetAccessToken returns the access token, whether obtained by the flow or entered by hand.
+func (c *OAuth2AuthDetailsComponent) GetAccessToken() string {
+	return strings.TrimSpace(c.inputs[oauth2AccessTokenField].Value())
+}
+
+// SetAccessToken replaces the access token.
+func (c *OAuth2AuthDetailsComponent) SetAccessToken(token string) {
+	c.inputs[oauth2AccessTokenField].SetValue(token)
+}
+
+// View renders the OAuth2AuthDetailsComponent: the input fields, the state of the
+// device flow and help text, within a styled border.
 // If width or height is zero or negative, it returns an empty string.
 func (c OAuth2AuthDetailsComponent) View() string {
 	if c.width <= 0 || c.height <= 0 {
 		return ""
 	}
-	style := styles.DefaultTheme.BorderStyle.Width(c.width).Height(c.height)
+
+	var lines []string
+	for i, input := range c.inputs {
+		marker := "  "
+		if c.active && i == c.focusedField {
+			marker = styles.DefaultTheme.SelectedItemStyle.Render("▶ ")
+		}
+		lines = append(lines, marker+input.View())
+		if err := inputError(input); err != nil {
+			lines = append(lines, "  "+renderInputError(err))
+		}
+	}
+	lines = append(lines, "")
+
+	if c.polling && c.code.UserCode != "" {
+		verification := c.code.VerificationURI
+		if c.code.VerificationURIComplete != "" {
+			verification = c.code.VerificationURIComplete
+		}
+		lines = append(lines,
+			"Open "+styles.DefaultTheme.URLTitleStyle.Render(verification),
+			"and enter the code "+styles.DefaultTheme.SelectedItemStyle.Render(c.code.UserCode),
+		)
+	}
+	if c.status != "" {
+		lines = append(lines, c.status)
+	}
+	lines = append(lines, styles.DefaultTheme.HelpTextStyle.Render("Up/Down: navigate fields • Ctrl+G: start device login"))
+
+	componentBorderStyle := styles.DefaultTheme.BorderStyle
 	if c.active {
-		style = styles.DefaultTheme.ActiveBorderStyle.Width(c.width).Height(c.height)
+		componentBorderStyle = styles.DefaultTheme.ActiveBorderStyle
 	}
-	return style.Render(fmt.Sprintf("OAuth2 Auth Details"))
+
+	innerWidth := max(c.width-componentBorderStyle.GetHorizontalFrameSize(), 0)
+	innerHeight := max(c.height-componentBorderStyle.GetVerticalFrameSize(), 0)
+
+	return componentBorderStyle.Width(c.width).Height(c.height).Render(
+		lipgloss.NewStyle().Width(innerWidth).Height(innerHeight).Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+	)
 }
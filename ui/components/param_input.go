@@ -2,6 +2,8 @@
 package components
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/RAshkettle/LazyPost/ui/styles"
@@ -38,6 +40,7 @@ func NewParamsContainer() ParamsContainer {
 		nameInput.Placeholder = "Name"
 		nameInput.Prompt = "" // No prompt, label will be above
 		nameInput.CharLimit = 35
+		nameInput.Validate = ValidateParamName
 
 		valueInput := textinput.New()
 		valueInput.Placeholder = "Value"
@@ -412,6 +415,11 @@ func (pc *ParamsContainer) View() string {
 			}
 		}
 
+		// Invalid names are outlined in the error color
+		if inputError(pc.Inputs[i].NameInput) != nil {
+			nameBoxStyle = nameBoxStyle.BorderForeground(styles.ErrorColor)
+		}
+
 		styledNameView := nameBoxStyle.Render(nameView)
 		styledValueView := valueBoxStyle.Render(valueView)
 
@@ -448,6 +456,9 @@ func (pc *ParamsContainer) View() string {
 	// It might be better to let it wrap or truncate based on lipgloss behavior if Width is set.
 	// For now, just render it. If actualContentWidth is too small, it will be truncated by the container.
 	rows = append(rows, helpTextStyle.Width(actualContentWidth).Render(helpText))
+	if errs := pc.FieldErrors(); len(errs) > 0 {
+		rows = append(rows, renderInputError(errs[0]))
+	}
 
 	containerContent := lipgloss.JoinVertical(lipgloss.Left, rows...)
 
@@ -459,6 +470,17 @@ func (pc *ParamsContainer) View() string {
 	return currentContainerStyle.Width(pc.Width).Height(pc.Height).Render(containerContent)
 }
 
+// FieldErrors returns the validation problems of the parameter rows.
+func (pc *ParamsContainer) FieldErrors() []FieldError {
+	var errs []FieldError
+	for i, p := range pc.Inputs {
+		if err := inputError(p.NameInput); err != nil {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("Params row %d name", i+1), Err: err})
+		}
+	}
+	return errs
+}
+
 // GetParams returns the current parameters as a map.
 func (pc *ParamsContainer) GetParams() map[string]string {
 	params := make(map[string]string)
@@ -472,6 +494,29 @@ func (pc *ParamsContainer) GetParams() map[string]string {
 	return params
 }
 
+// SetParams replaces all rows with the given parameters, sorted by name.
+// Parameters beyond the number of available rows are dropped; the number of rows filled is returned.
+func (pc *ParamsContainer) SetParams(params map[string]string) int {
+	pc.ClearParams()
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	filled := 0
+	for _, name := range names {
+		if filled >= len(pc.Inputs) {
+			break
+		}
+		pc.Inputs[filled].NameInput.SetValue(name)
+		pc.Inputs[filled].ValueInput.SetValue(params[name])
+		filled++
+	}
+	return filled
+}
+
 // ClearParams clears all input fields.
 func (pc *ParamsContainer) ClearParams() {
 	for i := range pc.Inputs {
@@ -486,6 +531,48 @@ func (pc *ParamsContainer) ClearParams() {
 	}
 }
 
+// FocusedText returns the value and cursor position of the focused input, if any.
+func (pc *ParamsContainer) FocusedText() (string, int, bool) {
+	if !pc.Active || pc.focusedRow < 0 || pc.focusedRow >= len(pc.Inputs) {
+		return "", 0, false
+	}
+	input := pc.Inputs[pc.focusedRow].NameInput
+	if pc.focusedCol == 1 {
+		input = pc.Inputs[pc.focusedRow].ValueInput
+	}
+	return input.Value(), input.Position(), true
+}
+
+// InsertText inserts text at the cursor of the focused input and reports whether there was one.
+func (pc *ParamsContainer) InsertText(text string) bool {
+	if !pc.Active || pc.focusedRow < 0 || pc.focusedRow >= len(pc.Inputs) {
+		return false
+	}
+	input := &pc.Inputs[pc.focusedRow].NameInput
+	if pc.focusedCol == 1 {
+		input = &pc.Inputs[pc.focusedRow].ValueInput
+	}
+	insertAtCursor(input, text)
+	return true
+}
+
+// StepFocused steps the number or date at the cursor of the focused value input by delta
+// (see StepValue) and reports whether there was one. Parameter names are not stepped.
+func (pc *ParamsContainer) StepFocused(delta int) bool {
+	if !pc.Active || pc.focusedCol != 1 || pc.focusedRow < 0 || pc.focusedRow >= len(pc.Inputs) {
+		return false
+	}
+	return stepInput(&pc.Inputs[pc.focusedRow].ValueInput, delta)
+}
+
+// insertAtCursor inserts text into input at its cursor and moves the cursor past it.
+func insertAtCursor(input *textinput.Model, text string) {
+	runes := []rune(input.Value())
+	pos := min(input.Position(), len(runes))
+	input.SetValue(string(runes[:pos]) + text + string(runes[pos:]))
+	input.SetCursor(pos + len([]rune(text)))
+}
+
 // IsAnyInputFocused checks if any text input within the ParamsContainer is currently focused.
 func (pc *ParamsContainer) IsAnyInputFocused() bool {
 	if pc.focusedRow < 0 || pc.focusedRow >= len(pc.Inputs) {
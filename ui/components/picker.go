@@ -0,0 +1,125 @@
+// Package components provides UI components for the LazyPost application.
+package components
+
+import (
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PickerItem is a single choice in a Picker.
+type PickerItem struct {
+	Label string // Label is the text shown in the list.
+	Value string // Value is returned to the caller when the item is chosen.
+}
+
+// Picker is a modal list shown over the main view, used to choose one of several values.
+// While visible it captures all key presses: up/down (or k/j) move the selection,
+// Enter chooses the selected item and Esc closes the picker.
+type Picker struct {
+	Title    string       // Title is shown above the list.
+	Items    []PickerItem // Items are the available choices.
+	Selected int          // Selected is the index of the highlighted item.
+	Visible  bool         // Visible indicates whether the picker is shown.
+	Status   string       // Status replaces the list while items are loading or when there are none.
+	Width    int          // Width of the picker in characters.
+}
+
+// NewPicker creates a hidden picker.
+func NewPicker() Picker {
+	return Picker{}
+}
+
+// Open shows the picker with a title and a status line, clearing any previous items.
+func (p *Picker) Open(title, status string) {
+	p.Title = title
+	p.Status = status
+	p.Items = nil
+	p.Selected = 0
+	p.Visible = true
+}
+
+// SetItems replaces the items and selects the first one. emptyStatus is shown when items is empty.
+func (p *Picker) SetItems(items []PickerItem, emptyStatus string) {
+	p.Items = items
+	p.Selected = 0
+	p.Status = ""
+	if len(items) == 0 {
+		p.Status = emptyStatus
+	}
+}
+
+// Close hides the picker.
+func (p *Picker) Close() {
+	p.Visible = false
+}
+
+// SetWidth sets the width of the picker in characters.
+func (p *Picker) SetWidth(width int) {
+	p.Width = width
+}
+
+// Next moves the selection to the next item, wrapping around to the first one.
+// It lets the key that opened the picker also cycle through it.
+func (p *Picker) Next() {
+	if len(p.Items) > 0 {
+		p.Selected = (p.Selected + 1) % len(p.Items)
+	}
+}
+
+// Update handles key presses while the picker is visible.
+// It returns the chosen item and true when Enter is pressed on an item; the picker is then closed.
+func (p *Picker) Update(msg tea.KeyMsg) (PickerItem, bool) {
+	switch msg.String() {
+	case "up", "k":
+		if p.Selected > 0 {
+			p.Selected--
+		}
+	case "down", "j":
+		if p.Selected < len(p.Items)-1 {
+			p.Selected++
+		}
+	case "enter":
+		if p.Selected < len(p.Items) {
+			p.Visible = false
+			return p.Items[p.Selected], true
+		}
+	case "esc":
+		p.Visible = false
+	}
+	return PickerItem{}, false
+}
+
+// View renders the picker as a bordered list, or an empty string when hidden.
+func (p Picker) View() string {
+	if !p.Visible {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString(styles.TitleStyle.Render(p.Title))
+	content.WriteString("\n\n")
+
+	if p.Status != "" {
+		content.WriteString(p.Status)
+		content.WriteString("\n")
+	}
+	for i, item := range p.Items {
+		if i == p.Selected {
+			content.WriteString(styles.DropdownSelectedItemStyle.Render(item.Label))
+		} else {
+			content.WriteString(styles.DropdownItemStyle.Render(item.Label))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styles.DefaultTheme.HelpTextStyle.Render("↑/↓: select • Enter: choose • Esc: close"))
+
+	style := styles.ActiveBorderStyle.Copy().Padding(0, 1)
+	if p.Width > 0 {
+		style = style.Width(p.Width)
+	}
+	return style.Render(content.String())
+}
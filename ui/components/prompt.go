@@ -0,0 +1,90 @@
+// Package components provides UI components for the LazyPost application.
+package components
+
+import (
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Prompt is a modal single-line text input shown over the main view.
+// While visible it captures all key presses: Enter submits the value, Esc closes the prompt
+// and Ctrl+Up/Down step the number at the cursor, e.g. a latency budget.
+type Prompt struct {
+	Title   string          // Title is shown above the input.
+	Input   textinput.Model // Input holds the entered text.
+	Visible bool            // Visible indicates whether the prompt is shown.
+	Width   int             // Width of the prompt in characters.
+}
+
+// NewPrompt creates a hidden prompt.
+func NewPrompt() Prompt {
+	input := textinput.New()
+	input.Prompt = "> "
+	input.CharLimit = 2048
+
+	return Prompt{Input: input}
+}
+
+// Open shows the prompt with a title, a placeholder and an initial value.
+// The returned command starts the cursor blinking.
+func (p *Prompt) Open(title, placeholder, value string) tea.Cmd {
+	p.Title = title
+	p.Input.Placeholder = placeholder
+	p.Input.SetValue(value)
+	p.Input.CursorEnd()
+	p.Visible = true
+	return p.Input.Focus()
+}
+
+// Close hides the prompt.
+func (p *Prompt) Close() {
+	p.Input.Blur()
+	p.Visible = false
+}
+
+// SetWidth sets the width of the prompt in characters.
+func (p *Prompt) SetWidth(width int) {
+	p.Width = width
+	p.Input.Width = max(width-6, 10) // Leave room for the border, padding and prompt
+}
+
+// Update handles key presses while the prompt is visible.
+// It returns the entered value and true when Enter is pressed; the prompt is then closed.
+func (p *Prompt) Update(msg tea.KeyMsg) (string, bool, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		p.Close()
+		return p.Input.Value(), true, nil
+	case "esc":
+		p.Close()
+		return "", false, nil
+	case "ctrl+up", "alt+=":
+		stepInput(&p.Input, 1)
+		return "", false, nil
+	case "ctrl+down", "alt+-":
+		stepInput(&p.Input, -1)
+		return "", false, nil
+	}
+
+	var cmd tea.Cmd
+	p.Input, cmd = p.Input.Update(msg)
+	return "", false, cmd
+}
+
+// View renders the prompt as a bordered box, or an empty string when hidden.
+func (p Prompt) View() string {
+	if !p.Visible {
+		return ""
+	}
+
+	content := styles.TitleStyle.Render(p.Title) + "\n\n" +
+		p.Input.View() + "\n\n" +
+		styles.DefaultTheme.HelpTextStyle.Render("Enter: confirm • Esc: cancel • Ctrl+↑/↓: step number")
+
+	style := styles.ActiveBorderStyle.Copy().Padding(0, 1)
+	if p.Width > 0 {
+		style = style.Width(p.Width)
+	}
+	return style.Render(content)
+}
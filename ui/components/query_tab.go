@@ -2,6 +2,8 @@
 package components
 
 import (
+	"strings"
+
 	"github.com/RAshkettle/LazyPost/ui/styles"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
@@ -217,10 +219,8 @@ func (q *QueryTab) Update(msg tea.Msg) tea.Cmd {
 					cmd = q.AuthInput.Update(msg)
 					cmds = append(cmds, cmd)
 				} else if currentInnerTab == "Headers" && q.HeadersInput.Active { // Check Active field
-					// Update returns (HeadersInputContainer, tea.Cmd)
-					newHeadersInput, headerCmd := q.HeadersInput.Update(msg)
-					q.HeadersInput = newHeadersInput
-					cmds = append(cmds, headerCmd)
+					cmd = q.HeadersInput.Update(msg)
+					cmds = append(cmds, cmd)
 				} else if currentInnerTab == "Body" && q.QueryBodyInput.Focused() {
 					q.QueryBodyInput, cmd = q.QueryBodyInput.Update(msg)
 					cmds = append(cmds, cmd)
@@ -238,10 +238,8 @@ func (q *QueryTab) Update(msg tea.Msg) tea.Cmd {
 				cmds = append(cmds, cmd)
 			}
 			if currentInnerTab == "Headers" {
-				// Update returns (HeadersInputContainer, tea.Cmd)
-				newHeadersInput, headerCmd := q.HeadersInput.Update(msg)
-				q.HeadersInput = newHeadersInput
-				cmds = append(cmds, headerCmd)
+				cmd = q.HeadersInput.Update(msg)
+				cmds = append(cmds, cmd)
 			}
 			// QueryBodyInput also needs updates for its state (e.g., cursor blink)
 			// even if it's not the active tab, but especially if it is.
@@ -430,6 +428,74 @@ func (q *QueryTab) GetBodyContent() string {
 	return q.QueryBodyInput.Value()
 }
 
+// SetBodyContent replaces the content of the QueryBodyInput (request body text area).
+func (q *QueryTab) SetBodyContent(content string) {
+	q.QueryBodyInput.SetValue(content)
+}
+
+// FocusedText returns the text and cursor position of the focused Params, Headers or Body field.
+// The body cursor is reported as a position within its current line, which is returned as the text.
+func (q *QueryTab) FocusedText() (string, int, bool) {
+	if !q.Active {
+		return "", 0, false
+	}
+	switch q.InnerTabs[q.ActiveInnerTab] {
+	case "Params":
+		return q.ParamsInput.FocusedText()
+	case "Headers":
+		return q.HeadersInput.FocusedText()
+	case "Body":
+		lines := strings.Split(q.QueryBodyInput.Value(), "\n")
+		row := q.QueryBodyInput.Line()
+		if row >= len(lines) {
+			return "", 0, false
+		}
+		info := q.QueryBodyInput.LineInfo()
+		return lines[row], info.StartColumn + info.ColumnOffset, true
+	}
+	return "", 0, false
+}
+
+// InsertText inserts text at the cursor of the focused Params, Headers or Body field
+// and reports whether there was one.
+func (q *QueryTab) InsertText(text string) bool {
+	if !q.Active {
+		return false
+	}
+	switch q.InnerTabs[q.ActiveInnerTab] {
+	case "Params":
+		return q.ParamsInput.InsertText(text)
+	case "Headers":
+		return q.HeadersInput.InsertText(text)
+	case "Body":
+		q.QueryBodyInput.InsertString(text)
+		return true
+	}
+	return false
+}
+
+// StepFocused steps the number or date at the cursor of the focused parameter or header
+// value by delta and reports whether there was one.
+func (q *QueryTab) StepFocused(delta int) bool {
+	if !q.Active {
+		return false
+	}
+	switch q.InnerTabs[q.ActiveInnerTab] {
+	case "Params":
+		return q.ParamsInput.StepFocused(delta)
+	case "Headers":
+		return q.HeadersInput.StepFocused(delta)
+	}
+	return false
+}
+
+// FieldErrors returns the validation problems of the params, headers and auth fields.
+func (q *QueryTab) FieldErrors() []FieldError {
+	errs := q.ParamsInput.FieldErrors()
+	errs = append(errs, q.HeadersInput.FieldErrors()...)
+	return append(errs, q.AuthInput.FieldErrors()...)
+}
+
 // IsAnyInputFocused checks if any interactive element within the currently active inner tab is focused.
 // This is used to determine context for keybindings or help text.
 func (q *QueryTab) IsAnyInputFocused() bool {
@@ -18,6 +18,7 @@ type ResultTab struct {
 	Active         bool              // Whether the component is currently active/focused
 	HeadersTab     HeadersContainer  // Container for displaying response headers
 	BodyTab        BodyContainer     // Container for displaying response body
+	Notice         string            // Short status shown next to the inner tabs, e.g. a retry countdown
 }
 
 // NewResultTab creates a new result tab component with predefined inner tabs.
@@ -38,6 +39,20 @@ func NewResultTab() ResultTab {
 	}
 }
 
+// SetCopyFilter sets a function applied to headers and text bodies before they are
+// copied to the clipboard, e.g. to redact credentials.
+func (r *ResultTab) SetCopyFilter(filter func(string) string) {
+	r.HeadersTab.SetCopyFilter(filter)
+	r.BodyTab.SetCopyFilter(filter)
+}
+
+// SetDisplayFilter sets a function applied to the headers and body text before they are
+// shown, e.g. to mask secrets. nil shows them as they are.
+func (r *ResultTab) SetDisplayFilter(filter func(string) string) {
+	r.HeadersTab.SetDisplayFilter(filter)
+	r.BodyTab.SetDisplayFilter(filter)
+}
+
 // SetWidth sets the width of the component in characters.
 func (r *ResultTab) SetWidth(width int) {
 	r.Width = width
@@ -148,6 +163,11 @@ func (r *ResultTab) Update(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
+// SetNotice sets the short status shown next to the inner tabs. An empty string hides it.
+func (r *ResultTab) SetNotice(notice string) {
+	r.Notice = notice
+}
+
 // SetHeadersContent sets the content for the headers tab.
 func (r *ResultTab) SetHeadersContent(content string) {
 	r.HeadersTab.SetContent(content)
@@ -158,6 +178,12 @@ func (r *ResultTab) SetBodyContent(content string) {
 	r.BodyTab.SetContent(content)
 }
 
+// SetBody sets the body tab from raw response bytes and their content type.
+// Binary bodies are summarised rather than rendered as text.
+func (r *ResultTab) SetBody(body []byte, contentType string) {
+	r.BodyTab.SetBody(body, contentType)
+}
+
 // SetContent sets the content for a specific inner tab by index.
 // This method is for backward compatibility.
 func (r *ResultTab) SetContent(tabIndex int, content string) {
@@ -207,6 +233,11 @@ func (r ResultTab) View() string {
 		}
 	}
 
+	// Show the notice, if any, after the tabs
+	if r.Notice != "" {
+		renderedInnerTabs = append(renderedInnerTabs, styles.HeaderNameStyle.Render(r.Notice))
+	}
+
 	// Join inner tabs horizontally
 	innerTabBar := lipgloss.JoinHorizontal(lipgloss.Top, renderedInnerTabs...)
 
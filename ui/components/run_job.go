@@ -0,0 +1,22 @@
+package components
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RunJobMsg asks the App to run work as a background job, like the requests it sends:
+// Esc cancels it, and it is delivered its result message or reported as failed.
+// Components return it for network calls and file writes, without access to the App's jobs.
+type RunJobMsg struct {
+	Name string                                                            // Name used in progress and error messages, e.g. "Save body"
+	Run  func(ctx context.Context, progress func(string)) (tea.Msg, error) // The work itself; it returns ctx.Err() when canceled
+}
+
+// RunJob returns a command that emits a RunJobMsg for run.
+func RunJob(name string, run func(ctx context.Context, progress func(string)) (tea.Msg, error)) tea.Cmd {
+	return func() tea.Msg {
+		return RunJobMsg{Name: name, Run: run}
+	}
+}
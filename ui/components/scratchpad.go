@@ -0,0 +1,211 @@
+package components
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Conversion is the result of one encoding or decoding of the scratchpad input.
+type Conversion struct {
+	Name   string // Name of the conversion, e.g. "Base64 encode"
+	Output string // Converted text, empty if Err is set
+	Err    error  // Why the input could not be converted
+}
+
+// Convert applies every scratchpad conversion to input.
+func Convert(input string) []Conversion {
+	conversions := []Conversion{
+		{Name: "Base64 encode", Output: base64.StdEncoding.EncodeToString([]byte(input))},
+		{Name: "Base64URL encode", Output: base64.RawURLEncoding.EncodeToString([]byte(input))},
+	}
+
+	decoded, err := decodeBase64(input)
+	conversions = append(conversions, Conversion{Name: "Base64 decode", Output: printable(decoded), Err: err})
+
+	conversions = append(conversions, Conversion{Name: "URL encode", Output: url.QueryEscape(input)})
+	unescaped, err := url.QueryUnescape(input)
+	conversions = append(conversions, Conversion{Name: "URL decode", Output: unescaped, Err: err})
+
+	conversions = append(conversions, Conversion{Name: "Hex encode", Output: hex.EncodeToString([]byte(input))})
+	unhexed, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(input), "0x"))
+	conversions = append(conversions, Conversion{Name: "Hex decode", Output: printable(unhexed), Err: err})
+
+	segments, err := decodeJWTSegments(input)
+	conversions = append(conversions, Conversion{Name: "JWT segments", Output: segments, Err: err})
+	return conversions
+}
+
+// decodeBase64 decodes standard or URL-safe base64, with or without padding.
+func decodeBase64(input string) ([]byte, error) {
+	input = strings.TrimSpace(input)
+	var err error
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		var decoded []byte
+		if decoded, err = encoding.DecodeString(input); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, err
+}
+
+// decodeJWTSegments decodes the header and payload of a JWT, joined by " . ".
+// The signature is binary and left out.
+func decodeJWTSegments(input string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(input), ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("a JWT has 3 segments, got %d", len(parts))
+	}
+
+	decoded := make([]string, 0, 2)
+	for i, part := range parts[:2] {
+		segment, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+		if err != nil {
+			return "", fmt.Errorf("segment %d: %w", i+1, err)
+		}
+		decoded = append(decoded, printable(segment))
+	}
+	return strings.Join(decoded, " . "), nil
+}
+
+// printable returns data as text, or a summary if it is not printable UTF-8.
+func printable(data []byte) string {
+	if !utf8.Valid(data) {
+		return fmt.Sprintf("(%d bytes of binary data)", len(data))
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return fmt.Sprintf("(%d bytes of binary data)", len(data))
+		}
+	}
+	return string(data)
+}
+
+// Scratchpad is a modal view that shows an input encoded and decoded in several ways
+// (base64, URL, hex and JWT segments) at once. While visible it captures all key presses.
+type Scratchpad struct {
+	Input    textinput.Model // Input holds the text being converted.
+	Visible  bool            // Visible indicates whether the scratchpad is shown.
+	Width    int             // Width of the scratchpad in characters.
+	selected int             // Index of the selected conversion.
+	status   string          // Result of the last copy, shown in the footer.
+}
+
+// NewScratchpad creates a hidden scratchpad.
+func NewScratchpad() Scratchpad {
+	input := textinput.New()
+	input.Prompt = "Input: "
+	input.Placeholder = "Text to encode or decode"
+
+	return Scratchpad{Input: input}
+}
+
+// Open shows the scratchpad, pre-filled with text.
+func (s *Scratchpad) Open(text string) tea.Cmd {
+	s.Input.SetValue(text)
+	s.Input.CursorEnd()
+	s.Visible = true
+	s.selected = 0
+	s.status = ""
+	return s.Input.Focus()
+}
+
+// SetWidth sets the width of the scratchpad in characters.
+func (s *Scratchpad) SetWidth(width int) {
+	s.Width = width
+	s.Input.Width = max(width-12, 10) // Leave room for the border, padding and prompt
+}
+
+// Update handles key presses while the scratchpad is visible: up/down select a conversion,
+// Enter copies it, Tab makes it the new input and Esc closes the scratchpad.
+func (s *Scratchpad) Update(msg tea.KeyMsg) tea.Cmd {
+	conversions := Convert(s.Input.Value())
+
+	switch msg.String() {
+	case "esc":
+		s.Input.Blur()
+		s.Visible = false
+		return nil
+	case "up":
+		s.selected = max(s.selected-1, 0)
+		return nil
+	case "down":
+		s.selected = min(s.selected+1, len(conversions)-1)
+		return nil
+	case "enter":
+		conversion := conversions[s.selected]
+		if conversion.Err != nil {
+			s.status = "Nothing to copy: " + conversion.Err.Error()
+			return nil
+		}
+		if err := clipboard.WriteAll(conversion.Output); err != nil {
+			s.status = fmt.Sprintf("Error copying to clipboard: %v", err)
+			return nil
+		}
+		s.status = "Copied " + conversion.Name + " result"
+		return nil
+	case "tab":
+		// Chain conversions, e.g. URL decode then base64 decode
+		if conversion := conversions[s.selected]; conversion.Err == nil {
+			s.Input.SetValue(conversion.Output)
+			s.Input.CursorEnd()
+		}
+		return nil
+	}
+
+	s.status = ""
+	var cmd tea.Cmd
+	s.Input, cmd = s.Input.Update(msg)
+	return cmd
+}
+
+// View renders the scratchpad, or an empty string when hidden.
+func (s Scratchpad) View() string {
+	if !s.Visible {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString(styles.TitleStyle.Render("Encode / decode"))
+	content.WriteString("\n\n")
+	content.WriteString(s.Input.View())
+	content.WriteString("\n\n")
+
+	outputWidth := max(s.Width-24, 10) // Leave room for the border, marker and names
+	for i, conversion := range Convert(s.Input.Value()) {
+		output := conversion.Output
+		if conversion.Err != nil {
+			output = styles.DefaultTheme.ErrorStyle.Render(conversion.Err.Error())
+		} else if output = strings.ReplaceAll(output, "\n", "⏎"); utf8.RuneCountInString(output) > outputWidth {
+			output = string([]rune(output)[:outputWidth-1]) + "…"
+		}
+
+		line := fmt.Sprintf("%-17s %s", conversion.Name, output)
+		if i == s.selected {
+			content.WriteString(styles.SelectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			content.WriteString("  " + line + "\n")
+		}
+	}
+
+	footer := "↑/↓: select • Enter: copy • Tab: use as input • Esc: close"
+	if s.status != "" {
+		footer = s.status + " • " + footer
+	}
+	content.WriteString("\n" + styles.DefaultTheme.HelpTextStyle.Render(footer))
+
+	style := styles.ActiveBorderStyle.Copy().Padding(0, 1)
+	if s.Width > 0 {
+		style = style.Width(s.Width)
+	}
+	return style.Render(content.String())
+}
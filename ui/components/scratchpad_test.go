@@ -0,0 +1,52 @@
+package components
+
+import "testing"
+
+// TestConvert checks each conversion of a sample input, including the decoders' errors.
+func TestConvert(t *testing.T) {
+	results := make(map[string]Conversion)
+	for _, c := range Convert("a b&c") {
+		results[c.Name] = c
+	}
+
+	want := map[string]string{
+		"Base64 encode":    "YSBiJmM=",
+		"Base64URL encode": "YSBiJmM",
+		"URL encode":       "a+b%26c",
+		"URL decode":       "a b&c",
+		"Hex encode":       "6120622663",
+	}
+	for name, output := range want {
+		if got := results[name]; got.Err != nil || got.Output != output {
+			t.Errorf("%s = %q, %v, want %q", name, got.Output, got.Err, output)
+		}
+	}
+	for _, name := range []string{"Base64 decode", "Hex decode", "JWT segments"} {
+		if results[name].Err == nil {
+			t.Errorf("%s of non-encoded input should fail, got %q", name, results[name].Output)
+		}
+	}
+}
+
+// TestConvertDecodes checks that encoded input is decoded, with or without padding.
+func TestConvertDecodes(t *testing.T) {
+	tests := []struct {
+		name, input, conversion, want string
+	}{
+		{"Padded base64", "aGVsbG8=", "Base64 decode", "hello"},
+		{"Unpadded base64url", "aGk_Pw", "Base64 decode", "hi??"},
+		{"Hex with prefix", "0x68656c6c6f", "Hex decode", "hello"},
+		{"Binary", "AAEC", "Base64 decode", "(3 bytes of binary data)"},
+		{"JWT", "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.c2ln", "JWT segments", `{"alg":"HS256"} . {"sub":"1"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, c := range Convert(tt.input) {
+				if c.Name == tt.conversion && (c.Err != nil || c.Output != tt.want) {
+					t.Errorf("%s(%q) = %q, %v, want %q", tt.conversion, tt.input, c.Output, c.Err, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package components
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// numberPattern matches an integer or decimal number, with an optional leading minus.
+var numberPattern = regexp.MustCompile(`-?\d+(?:\.\d+)?`)
+
+// layoutUnit is the calendar unit stepped when the cursor is within [start, end] of a date.
+type layoutUnit struct {
+	start, end int    // Byte offsets of the unit in the formatted date, end inclusive of the cursor after it
+	unit       string // "year", "month", "day", "hour", "minute" or "second"
+}
+
+// stepLayouts are the date-like values that are stepped by calendar unit rather than as
+// numbers, with the unit under each part of the layout.
+var stepLayouts = []struct {
+	layout string       // time.Parse layout the whole value must match
+	units  []layoutUnit // Unit under each part of the layout
+}{
+	{time.RFC3339, []layoutUnit{{0, 4, "year"}, {5, 7, "month"}, {8, 10, "day"}, {11, 13, "hour"}, {14, 16, "minute"}, {17, 99, "second"}}},
+	{"2006-01-02T15:04:05", []layoutUnit{{0, 4, "year"}, {5, 7, "month"}, {8, 10, "day"}, {11, 13, "hour"}, {14, 16, "minute"}, {17, 99, "second"}}},
+	{"2006-01-02", []layoutUnit{{0, 4, "year"}, {5, 7, "month"}, {8, 99, "day"}}},
+}
+
+// StepValue adds delta to the number or date part at the cursor of text, like Vim's
+// Ctrl+A and Ctrl+X. Dates ("2024-01-31") and RFC 3339 timestamps are stepped by the unit
+// under the cursor with calendar carry; otherwise the number under, just before or else
+// after the cursor is stepped in its last decimal place, keeping leading zeros.
+// It returns the new text, the new cursor and false if there is nothing to step.
+func StepValue(text string, cursor, delta int) (string, int, bool) {
+	runes := []rune(text)
+	cursor = max(0, min(cursor, len(runes)))
+	offset := len(string(runes[:cursor])) // Cursor as a byte offset
+
+	if stepped, ok := stepDate(text, offset, delta); ok {
+		return stepped, cursor, true
+	}
+
+	matches := numberPattern.FindAllStringIndex(text, -1)
+	var match []int
+	for _, m := range matches {
+		if offset >= m[0] && offset <= m[1] {
+			match = m
+			break
+		}
+		if m[0] > offset {
+			match = m
+			break
+		}
+	}
+	if match == nil {
+		return text, cursor, false
+	}
+	start, end := match[0], match[1]
+	// A minus sign after a letter or digit is a separator, as in "v1-2", not a sign
+	if text[start] == '-' && start > 0 && isAlphanumeric(text[start-1]) {
+		start++
+	}
+
+	stepped := stepNumber(text[start:end], delta)
+	result := text[:start] + stepped + text[end:]
+	newCursor := len([]rune(text[:start] + stepped))
+	if offset < start {
+		newCursor = cursor // The number was after the cursor; keep the cursor where it was
+	}
+	return result, newCursor, true
+}
+
+// stepNumber adds delta units of its last decimal place to number, keeping its decimals
+// and, for integers written with leading zeros, its width.
+func stepNumber(number string, delta int) string {
+	decimals := 0
+	if dot := strings.IndexByte(number, '.'); dot >= 0 {
+		decimals = len(number) - dot - 1
+	}
+	if decimals == 0 {
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return number
+		}
+		digits := strings.TrimPrefix(number, "-")
+		stepped := strconv.FormatInt(n+int64(delta), 10)
+		if len(digits) > 1 && digits[0] == '0' {
+			sign := ""
+			if strings.HasPrefix(stepped, "-") {
+				sign, stepped = "-", stepped[1:]
+			}
+			stepped = sign + strings.Repeat("0", max(0, len(digits)-len(stepped))) + stepped
+		}
+		return stepped
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return number
+	}
+	return strconv.FormatFloat(f+float64(delta)*math.Pow10(-decimals), 'f', decimals, 64)
+}
+
+// stepDate steps the calendar unit at byte offset of text if the whole text is a date
+// or timestamp, and reports whether it was.
+func stepDate(text string, offset, delta int) (string, bool) {
+	for _, l := range stepLayouts {
+		t, err := time.Parse(l.layout, text)
+		if err != nil {
+			continue
+		}
+		unit := l.units[len(l.units)-1].unit
+		for _, u := range l.units {
+			if offset >= u.start && offset <= u.end {
+				unit = u.unit
+				break
+			}
+		}
+		switch unit {
+		case "year":
+			t = t.AddDate(delta, 0, 0)
+		case "month":
+			t = t.AddDate(0, delta, 0)
+		case "day":
+			t = t.AddDate(0, 0, delta)
+		case "hour":
+			t = t.Add(time.Duration(delta) * time.Hour)
+		case "minute":
+			t = t.Add(time.Duration(delta) * time.Minute)
+		case "second":
+			t = t.Add(time.Duration(delta) * time.Second)
+		}
+		return t.Format(l.layout), true
+	}
+	return "", false
+}
+
+// isAlphanumeric reports whether c is an ASCII letter or digit.
+func isAlphanumeric(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// stepInput steps the number or date at the cursor of input and reports whether there was one.
+func stepInput(input *textinput.Model, delta int) bool {
+	value, cursor, ok := StepValue(input.Value(), input.Position(), delta)
+	if !ok {
+		return false
+	}
+	input.SetValue(value)
+	input.SetCursor(cursor)
+	return true
+}
@@ -0,0 +1,38 @@
+package components
+
+import "testing"
+
+// TestStepValue checks stepping numbers and dates at the cursor.
+func TestStepValue(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		cursor     int
+		delta      int
+		expected   string
+		wantCursor int
+		wantOK     bool
+	}{
+		{"Integer under cursor", "42", 1, 1, "43", 2, true},
+		{"Number just before cursor", "/items/99", 9, 1, "/items/100", 10, true},
+		{"Number after cursor", "page=7&size=20", 0, -1, "page=6&size=20", 0, true},
+		{"Negative result", "0", 0, -1, "-1", 2, true},
+		{"Leading zeros kept", "007", 3, 1, "008", 3, true},
+		{"Decimal stepped in last place", "1.25", 4, 1, "1.26", 4, true},
+		{"Minus after a letter is a separator", "v1-2", 4, 1, "v1-3", 4, true},
+		{"Day with carry", "2024-01-31", 9, 1, "2024-02-01", 9, true},
+		{"Month of a date", "2024-01-31", 6, 1, "2024-03-02", 6, true},
+		{"Hour of a timestamp", "2024-01-31T23:30:00Z", 12, 1, "2024-02-01T00:30:00Z", 12, true},
+		{"No number", "abc", 1, 1, "abc", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cursor, ok := StepValue(tt.text, tt.cursor, tt.delta)
+			if got != tt.expected || cursor != tt.wantCursor || ok != tt.wantOK {
+				t.Errorf("StepValue(%q, %d, %d) = %q, %d, %v, want %q, %d, %v",
+					tt.text, tt.cursor, tt.delta, got, cursor, ok, tt.expected, tt.wantCursor, tt.wantOK)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ type TabsContainer struct {
 	TabContents []string    // Default content for each tab (used as fallback)
 	QueryTab    QueryTab    // The query tab component with its inner tabs
 	ResultTab   ResultTab   // The result tab component with its inner tabs
+
+	unseenResult bool // Whether a response arrived that the Result tab has not been opened for
 }
 
 // NewTabsContainer creates a new tab container with Query and Result tabs.
@@ -80,16 +82,30 @@ func (t *TabsContainer) SwitchToTab(tabIndex int) {
 	if tabIndex >= 0 && tabIndex < len(t.Tabs) {
 		t.ActiveTab = tabIndex
 	}
+	if t.ActiveTab == 1 {
+		t.unseenResult = false
+	}
+}
+
+// MarkResultUnseen badges the Result tab label until the Result tab is opened.
+func (t *TabsContainer) MarkResultUnseen() {
+	t.unseenResult = t.ActiveTab != 1
+}
+
+// HasUnseenResult reports whether the Result tab holds a response that has not been looked at.
+func (t *TabsContainer) HasUnseenResult() bool {
+	return t.unseenResult
 }
 
 // Update processes input messages and updates the container state.
 // It handles alt+key combinations for tab switching and delegates
 // tab/shift+tab navigation to the appropriate inner tab component.
-func (t *TabsContainer) Update(msg tea.Msg) {
+// It returns any command produced by the inner tab (e.g. a toast request).
+func (t *TabsContainer) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if !t.Active {
-			return
+			return nil
 		}
 		
 		switch msg.String() {
@@ -102,19 +118,20 @@ func (t *TabsContainer) Update(msg tea.Msg) {
 		case "tab", "shift+tab":
 			// Handle tab/shift+tab events in the active tab
 			if t.ActiveTab == 0 {
-				t.QueryTab.Update(msg)
+				return t.QueryTab.Update(msg)
 			} else if t.ActiveTab == 1 {
-				t.ResultTab.Update(msg)
+				return t.ResultTab.Update(msg)
 			}
 		default:
 			// Pass other messages to the active tab
 			if t.ActiveTab == 0 {
-				t.QueryTab.Update(msg)
+				return t.QueryTab.Update(msg)
 			} else if t.ActiveTab == 1 {
-				t.ResultTab.Update(msg)
+				return t.ResultTab.Update(msg)
 			}
 		}
 	}
+	return nil
 }
 
 // View renders the tab container component with the active tab's content.
@@ -163,6 +180,9 @@ func (t TabsContainer) View() string {
 		
 		// Create tab text with Alt+number hotkey
 		tabText := fmt.Sprintf("(Alt+%d) %s", index+3, text)
+		if index == 1 && t.unseenResult {
+			tabText += " ●" // New response not looked at yet
+		}
 		return baseStyle.Render(tabText)
 	}
 	
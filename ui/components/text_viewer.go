@@ -0,0 +1,87 @@
+// Package components provides UI components for the LazyPost application.
+package components
+
+import (
+	"fmt"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TextViewer is a modal, scrollable view of read-only text shown over the main view,
+// such as the raw request preview. While visible it captures all key presses:
+// Up/Down and PgUp/PgDn scroll, y copies the text and Esc closes the viewer.
+type TextViewer struct {
+	Title      string              // Title is shown above the text.
+	Viewport   viewport.Model      // Viewport scrolls the text.
+	Visible    bool                // Visible indicates whether the viewer is shown.
+	Width      int                 // Width of the viewer in characters.
+	Height     int                 // Height of the viewer in characters.
+	content    string              // content is the text as shown, possibly styled.
+	copyFilter func(string) string // copyFilter, if set, transforms text before it is copied (e.g. to redact credentials).
+}
+
+// NewTextViewer creates a hidden text viewer.
+func NewTextViewer() TextViewer {
+	return TextViewer{Viewport: viewport.New(0, 0)}
+}
+
+// Open shows the viewer with a title and content, scrolled to the top.
+func (t *TextViewer) Open(title, content string) {
+	t.Title = title
+	t.content = content
+	t.Viewport.SetContent(content)
+	t.Viewport.GotoTop()
+	t.Visible = true
+}
+
+// SetCopyFilter sets a function applied to the text before it is copied to the clipboard.
+func (t *TextViewer) SetCopyFilter(filter func(string) string) {
+	t.copyFilter = filter
+}
+
+// SetSize sets the size of the viewer in characters.
+func (t *TextViewer) SetSize(width, height int) {
+	t.Width = width
+	t.Height = height
+	t.Viewport.Width = max(width-4, 0)   // Border and padding
+	t.Viewport.Height = max(height-6, 0) // Border, title and help lines
+}
+
+// Update handles key presses while the viewer is visible.
+func (t *TextViewer) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "q":
+		t.Visible = false
+		return nil
+	case "y":
+		text := ansi.Strip(t.content)
+		if t.copyFilter != nil {
+			text = t.copyFilter(text)
+		}
+		if err := clipboard.WriteAll(text); err != nil {
+			return ShowToast(fmt.Sprintf("Error copying to clipboard: %v", err))
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	t.Viewport, cmd = t.Viewport.Update(msg)
+	return cmd
+}
+
+// View renders the viewer, or an empty string when hidden.
+func (t TextViewer) View() string {
+	if !t.Visible {
+		return ""
+	}
+
+	content := styles.TitleStyle.Render(t.Title) + "\n\n" +
+		t.Viewport.View() + "\n\n" +
+		styles.DefaultTheme.HelpTextStyle.Render(fmt.Sprintf("↑/↓ scroll (%3.f%%) • y: copy • Esc: close", t.Viewport.ScrollPercent()*100))
+
+	return styles.ActiveBorderStyle.Copy().Padding(0, 1).Width(t.Width).Render(content)
+}
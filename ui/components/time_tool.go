@@ -0,0 +1,217 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// nowOffsetPattern matches "now", optionally followed by an offset such as "+1h" or "-7d12h".
+var nowOffsetPattern = regexp.MustCompile(`^now\s*(?:([+-])\s*(\S+))?$`)
+
+// ParseTime parses a time expression: "now" with an optional offset ("now-15m", "now+7d"),
+// Unix seconds or milliseconds, an RFC 3339 timestamp, an HTTP date or a plain date.
+func ParseTime(expression string, now time.Time) (time.Time, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return now, nil
+	}
+
+	if m := nowOffsetPattern.FindStringSubmatch(strings.ToLower(expression)); m != nil {
+		if m[2] == "" {
+			return now, nil
+		}
+		offset, err := parseOffset(m[2])
+		if err != nil {
+			return time.Time{}, err
+		}
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return now.Add(offset), nil
+	}
+
+	if epoch, err := strconv.ParseInt(expression, 10, 64); err == nil {
+		// Values this large are milliseconds; as seconds they would be past the year 5000
+		if epoch > 99999999999 || epoch < -99999999999 {
+			return time.UnixMilli(epoch), nil
+		}
+		return time.Unix(epoch, 0), nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, http.TimeFormat, time.RFC1123Z, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, expression); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("use now±offset, Unix seconds or milliseconds, RFC 3339 or an HTTP date")
+}
+
+// parseOffset parses a duration that may start with a number of days, e.g. "7d", "1d12h" or "90m".
+func parseOffset(s string) (time.Duration, error) {
+	var days time.Duration
+	if before, after, found := strings.Cut(s, "d"); found {
+		n, err := strconv.Atoi(before)
+		if err != nil {
+			return 0, fmt.Errorf("invalid offset %q", s)
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		if s = after; s == "" {
+			return days, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid offset %q", s)
+	}
+	return days + d, nil
+}
+
+// TimeFormat is one way of writing a time in a request.
+type TimeFormat struct {
+	Name  string // Name of the format, e.g. "Unix seconds"
+	Value string // The time in this format
+}
+
+// FormatTime writes t in the formats commonly used by APIs.
+func FormatTime(t time.Time) []TimeFormat {
+	return []TimeFormat{
+		{"Unix seconds", strconv.FormatInt(t.Unix(), 10)},
+		{"Unix millis", strconv.FormatInt(t.UnixMilli(), 10)},
+		{"RFC 3339 (UTC)", t.UTC().Format(time.RFC3339)},
+		{"RFC 3339 (local)", t.Local().Format(time.RFC3339)},
+		{"HTTP date", t.UTC().Format(http.TimeFormat)},
+		{"Date", t.UTC().Format("2006-01-02")},
+	}
+}
+
+// TimeTool is a modal view that converts a time expression such as "now+1h" or an epoch
+// into common timestamp formats, ready to be inserted into the focused field.
+// While visible it captures all key presses.
+type TimeTool struct {
+	Input    textinput.Model // Input holds the time expression.
+	Visible  bool            // Visible indicates whether the time tool is shown.
+	Width    int             // Width of the time tool in characters.
+	now      time.Time       // Time "now" refers to, fixed when the tool is opened.
+	selected int             // Index of the selected format.
+	status   string          // Result of the last copy, shown in the footer.
+}
+
+// NewTimeTool creates a hidden time tool.
+func NewTimeTool() TimeTool {
+	input := textinput.New()
+	input.Prompt = "Time: "
+	input.Placeholder = "now, now-15m, now+7d, 1700000000, 2024-01-31T12:00:00Z"
+
+	return TimeTool{Input: input}
+}
+
+// Open shows the time tool with "now" entered.
+func (t *TimeTool) Open() tea.Cmd {
+	t.Input.SetValue("now")
+	t.Input.CursorEnd()
+	t.Visible = true
+	t.now = time.Now()
+	t.selected = 0
+	t.status = ""
+	return t.Input.Focus()
+}
+
+// SetWidth sets the width of the time tool in characters.
+func (t *TimeTool) SetWidth(width int) {
+	t.Width = width
+	t.Input.Width = max(width-12, 10) // Leave room for the border, padding and prompt
+}
+
+// Update handles key presses while the time tool is visible: up/down select a format,
+// Enter closes the tool and returns the selected value to insert, Tab copies it and Esc
+// closes the tool without inserting anything.
+func (t *TimeTool) Update(msg tea.KeyMsg) (string, bool, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		t.close()
+		return "", false, nil
+	case "up":
+		t.selected = max(t.selected-1, 0)
+		return "", false, nil
+	case "down":
+		t.selected = min(t.selected+1, len(FormatTime(t.now))-1)
+		return "", false, nil
+	case "enter", "tab":
+		parsed, err := ParseTime(t.Input.Value(), t.now)
+		if err != nil {
+			t.status = err.Error()
+			return "", false, nil
+		}
+		value := FormatTime(parsed)[t.selected].Value
+		if msg.String() == "enter" {
+			t.close()
+			return value, true, nil
+		}
+		if err := clipboard.WriteAll(value); err != nil {
+			t.status = fmt.Sprintf("Error copying to clipboard: %v", err)
+		} else {
+			t.status = "Copied " + value
+		}
+		return "", false, nil
+	}
+
+	t.status = ""
+	var cmd tea.Cmd
+	t.Input, cmd = t.Input.Update(msg)
+	return "", false, cmd
+}
+
+// close hides the time tool.
+func (t *TimeTool) close() {
+	t.Input.Blur()
+	t.Visible = false
+}
+
+// View renders the time tool, or an empty string when hidden.
+func (t TimeTool) View() string {
+	if !t.Visible {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString(styles.TitleStyle.Render("Time"))
+	content.WriteString("\n\n")
+	content.WriteString(t.Input.View())
+	content.WriteString("\n\n")
+
+	parsed, err := ParseTime(t.Input.Value(), t.now)
+	if err != nil {
+		content.WriteString(styles.DefaultTheme.ErrorStyle.Render(err.Error()) + "\n")
+	} else {
+		for i, format := range FormatTime(parsed) {
+			line := fmt.Sprintf("%-17s %s", format.Name, format.Value)
+			if i == t.selected {
+				content.WriteString(styles.SelectedItemStyle.Render("> "+line) + "\n")
+			} else {
+				content.WriteString("  " + line + "\n")
+			}
+		}
+	}
+
+	footer := "↑/↓: select • Enter: insert into field • Tab: copy • Esc: close"
+	if t.status != "" {
+		footer = t.status + " • " + footer
+	}
+	content.WriteString("\n" + styles.DefaultTheme.HelpTextStyle.Render(footer))
+
+	style := styles.ActiveBorderStyle.Copy().Padding(0, 1)
+	if t.Width > 0 {
+		style = style.Width(t.Width)
+	}
+	return style.Render(content.String())
+}
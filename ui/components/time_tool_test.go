@@ -0,0 +1,57 @@
+package components
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseTime checks each supported kind of time expression.
+func TestParseTime(t *testing.T) {
+	now := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		want       time.Time
+	}{
+		{"now", now},
+		{"", now},
+		{"now-15m", now.Add(-15 * time.Minute)},
+		{"now + 7d", now.Add(7 * 24 * time.Hour)},
+		{"NOW+1d12h", now.Add(36 * time.Hour)},
+		{"1700000000", time.Unix(1700000000, 0)},
+		{"1700000000123", time.UnixMilli(1700000000123)},
+		{"2024-01-31T13:00:00+01:00", now},
+		{"Wed, 31 Jan 2024 12:00:00 GMT", now},
+		{"2024-01-31", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTime(tt.expression, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %v, %v, want %v", tt.expression, got, err, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"tomorrow", "now+1x", "now-xd"} {
+		if _, err := ParseTime(invalid, now); err == nil {
+			t.Errorf("ParseTime(%q) should fail", invalid)
+		}
+	}
+}
+
+// TestFormatTime checks the formats that do not depend on the local time zone.
+func TestFormatTime(t *testing.T) {
+	formats := FormatTime(time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC))
+
+	want := map[string]string{
+		"Unix seconds":   "1706702400",
+		"Unix millis":    "1706702400000",
+		"RFC 3339 (UTC)": "2024-01-31T12:00:00Z",
+		"HTTP date":      "Wed, 31 Jan 2024 12:00:00 GMT",
+	}
+	for _, format := range formats {
+		if value, ok := want[format.Name]; ok && format.Value != value {
+			t.Errorf("%s = %q, want %q", format.Name, format.Value, value)
+		}
+	}
+}
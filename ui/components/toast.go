@@ -12,6 +12,20 @@ import (
 // It is used for automatic dismissal timing of toast notifications.
 type TickMsg time.Time
 
+// ShowToastMsg asks the App to display a toast notification.
+// Components return it from commands when they need to surface feedback,
+// such as clipboard or file errors, without access to the App's toast.
+type ShowToastMsg struct {
+	Message string // The text message to display in the toast
+}
+
+// ShowToast returns a command that emits a ShowToastMsg with the given message.
+func ShowToast(message string) tea.Cmd {
+	return func() tea.Msg {
+		return ShowToastMsg{Message: message}
+	}
+}
+
 // Toast represents a temporary notification that displays messages to the user.
 // It can show success, warning, or error messages with a dismissal option.
 type Toast struct {
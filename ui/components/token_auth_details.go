@@ -33,6 +33,11 @@ func NewTokenAuthDetailsComponent() TokenAuthDetailsComponent {
 	}
 }
 
+// SetMasked sets whether the token is shown as asterisks.
+func (c *TokenAuthDetailsComponent) SetMasked(masked bool) {
+	c.tokenInput.EchoMode = echoMode(masked)
+}
+
 // SetActive sets the active state of the component.
 // When active, the token input field gains focus. When inactive, it loses focus.
 func (c *TokenAuthDetailsComponent) SetActive(active bool) {
@@ -116,6 +121,11 @@ func (c TokenAuthDetailsComponent) View() string {
 	)
 }
 
+// SetToken replaces the value of the token input field.
+func (c *TokenAuthDetailsComponent) SetToken(token string) {
+	c.tokenInput.SetValue(token)
+}
+
 // GetToken returns the current value of the token input field.
 func (c *TokenAuthDetailsComponent) GetToken() string {
 	return c.tokenInput.Value()
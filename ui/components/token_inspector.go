@@ -0,0 +1,161 @@
+// Package components provides UI components for the LazyPost application.
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/tokens"
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// timeClaims are the JWT claims holding NumericDate values, shown as local times.
+var timeClaims = map[string]bool{"exp": true, "iat": true, "nbf": true, "auth_time": true}
+
+// TokenInspectorTickMsg refreshes the expiry countdown of the token inspector.
+type TokenInspectorTickMsg time.Time
+
+// TokenInspector is a modal view that decodes a pasted token: the header, claims,
+// signature algorithm and a live expiry countdown for JWTs, and basic facts for opaque tokens.
+// While visible it captures all key presses; Esc closes it.
+type TokenInspector struct {
+	Input   textinput.Model // Input holds the token being inspected.
+	Visible bool            // Visible indicates whether the inspector is shown.
+	Width   int             // Width of the inspector in characters.
+	now     time.Time       // now is the time the countdown is computed from, updated every second.
+}
+
+// NewTokenInspector creates a hidden token inspector.
+func NewTokenInspector() TokenInspector {
+	input := textinput.New()
+	input.Prompt = "Token: "
+	input.Placeholder = "Paste a JWT or opaque token"
+
+	return TokenInspector{Input: input}
+}
+
+// Open shows the inspector, pre-filled with token, and starts the countdown.
+func (t *TokenInspector) Open(token string) tea.Cmd {
+	t.Input.SetValue(token)
+	t.Input.CursorEnd()
+	t.Visible = true
+	t.now = time.Now()
+	return tea.Batch(t.Input.Focus(), tokenInspectorTick())
+}
+
+// SetWidth sets the width of the inspector in characters.
+func (t *TokenInspector) SetWidth(width int) {
+	t.Width = width
+	t.Input.Width = max(width-12, 10) // Leave room for the border, padding and prompt
+}
+
+// tokenInspectorTick schedules the next countdown refresh.
+func tokenInspectorTick() tea.Cmd {
+	return tea.Tick(time.Second, func(now time.Time) tea.Msg {
+		return TokenInspectorTickMsg(now)
+	})
+}
+
+// Tick refreshes the countdown and schedules the next refresh while the inspector is visible.
+func (t *TokenInspector) Tick(msg TokenInspectorTickMsg) tea.Cmd {
+	if !t.Visible {
+		return nil
+	}
+	t.now = time.Time(msg)
+	return tokenInspectorTick()
+}
+
+// Update handles key presses while the inspector is visible.
+func (t *TokenInspector) Update(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() == "esc" {
+		t.Input.Blur()
+		t.Visible = false
+		return nil
+	}
+
+	var cmd tea.Cmd
+	t.Input, cmd = t.Input.Update(msg)
+	return cmd
+}
+
+// View renders the inspector, or an empty string when hidden.
+func (t TokenInspector) View() string {
+	if !t.Visible {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString(styles.TitleStyle.Render("Token inspector"))
+	content.WriteString("\n\n")
+	content.WriteString(t.Input.View())
+	content.WriteString("\n\n")
+	content.WriteString(t.details())
+	content.WriteString("\n\n")
+	content.WriteString(styles.DefaultTheme.HelpTextStyle.Render("Signatures are not verified • Esc: close"))
+
+	style := styles.ActiveBorderStyle.Copy().Padding(0, 1)
+	if t.Width > 0 {
+		style = style.Width(t.Width)
+	}
+	return style.Render(content.String())
+}
+
+// details describes the entered token.
+func (t TokenInspector) details() string {
+	value := strings.TrimSpace(t.Input.Value())
+	if value == "" {
+		return "Nothing to inspect."
+	}
+
+	jwt, err := tokens.DecodeJWT(value)
+	if err != nil {
+		lines := []string{styles.HeaderNameStyle.Render("Opaque token") + " (not a JWT: " + err.Error() + ")"}
+		lines = append(lines, tokens.DescribeOpaque(value)...)
+		return strings.Join(lines, "\n")
+	}
+
+	var lines []string
+	algorithm := jwt.Algorithm()
+	if algorithm == "" {
+		algorithm = "(none given)"
+	}
+	lines = append(lines, fmt.Sprintf("%s %s, %d byte signature", styles.HeaderNameStyle.Render("Algorithm:"), algorithm, len(jwt.Signature)))
+	if status := jwt.ExpiryStatus(t.now); status != "" {
+		lines = append(lines, styles.HeaderNameStyle.Render("Expiry:")+" "+status)
+	}
+
+	lines = append(lines, "", styles.HeaderNameStyle.Render("Header"))
+	lines = append(lines, formatClaims(jwt.Header)...)
+	lines = append(lines, "", styles.HeaderNameStyle.Render("Claims"))
+	lines = append(lines, formatClaims(jwt.Claims)...)
+	return strings.Join(lines, "\n")
+}
+
+// formatClaims renders claims as sorted "name: value" lines, with NumericDate claims as local times.
+func formatClaims(claims map[string]any) []string {
+	names := make([]string, 0, len(claims))
+	for name := range claims {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		value := claims[name]
+		if seconds, ok := value.(float64); ok && timeClaims[name] {
+			lines = append(lines, fmt.Sprintf("  %s: %s", name, time.Unix(int64(seconds), 0).Format(time.RFC1123)))
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded = []byte(fmt.Sprint(value))
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", name, encoded))
+	}
+	return lines
+}
@@ -57,6 +57,23 @@ func (u *URLInput) GetText() string {
 	return u.TextInput.Value()
 }
 
+// SetText replaces the URL text and moves the cursor to the end.
+func (u *URLInput) SetText(text string) {
+	u.TextInput.SetValue(text)
+	u.TextInput.CursorEnd()
+}
+
+// InsertText inserts text at the cursor.
+func (u *URLInput) InsertText(text string) {
+	insertAtCursor(&u.TextInput, text)
+}
+
+// Step steps the number or date at the cursor by delta (see StepValue), e.g. an ID in the
+// path, and reports whether there was one.
+func (u *URLInput) Step(delta int) bool {
+	return stepInput(&u.TextInput, delta)
+}
+
 // SelectAllText selects all text in the input field.
 // This is used when focusing the input to allow quick replacement of the URL.
 func (u *URLInput) SelectAllText() {
@@ -0,0 +1,85 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// Inputs are validated through the Validate hook of textinput.Model: each component sets
+// the checks that apply to its fields when creating them, its View marks fields that fail
+// them with renderInputError, and its FieldErrors method reports them so the app can
+// refuse to send a request with invalid fields.
+
+// FieldError is a validation problem in a named input field.
+type FieldError struct {
+	Field string // Field describes where the input is, e.g. "Headers row 2 (Accept)"
+	Err   error  // Err describes the problem
+}
+
+// Error returns the field and the problem.
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// ValidateHeaderValue rejects characters outside printable ASCII. Go sends them as raw
+// UTF-8 bytes, which HTTP treats as opaque and many servers reject or mangle.
+func ValidateHeaderValue(value string) error {
+	for _, r := range value {
+		if (r < ' ' && r != '\t') || r > '~' {
+			return fmt.Errorf("%q is not printable ASCII; percent-encode it", r)
+		}
+	}
+	return nil
+}
+
+// ValidateParamName rejects names that look like a whole "name=value" pair or several
+// parameters, which would be sent encoded as one odd name.
+func ValidateParamName(name string) error {
+	if strings.ContainsAny(name, "=&") {
+		return errors.New("'=' and '&' are encoded in a name; put the value in the Value column")
+	}
+	return nil
+}
+
+// ValidateBasicUsername rejects colons, which the Basic scheme uses to separate the user
+// name from the password (RFC 7617).
+func ValidateBasicUsername(username string) error {
+	if strings.Contains(username, ":") {
+		return errors.New("a Basic auth user name cannot contain ':'")
+	}
+	return nil
+}
+
+// ValidateEndpointURL accepts an empty value or an absolute http(s) URL.
+func ValidateEndpointURL(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("expected an http:// or https:// URL")
+	}
+	return nil
+}
+
+// inputError returns the validation problem of input, or nil if it is valid or has no checks.
+func inputError(input textinput.Model) error {
+	if input.Validate == nil {
+		return nil
+	}
+	return input.Validate(input.Value())
+}
+
+// renderInputError renders err as an inline message below a field, or "" when err is nil.
+func renderInputError(err error) string {
+	if err == nil {
+		return ""
+	}
+	return styles.DefaultTheme.ErrorStyle.Render("✗ " + err.Error())
+}
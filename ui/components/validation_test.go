@@ -0,0 +1,51 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestValidators tests the checks used by the input validation hooks.
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) error
+		value    string
+		wantErr  bool
+	}{
+		{"header ASCII", ValidateHeaderValue, "Bearer abc\tdef", false},
+		{"header non-ASCII", ValidateHeaderValue, "café", true},
+		{"param name", ValidateParamName, "page size", false},
+		{"param pair", ValidateParamName, "page=2", true},
+		{"basic user", ValidateBasicUsername, "alice@example.com", false},
+		{"basic user colon", ValidateBasicUsername, "alice:secret", true},
+		{"endpoint empty", ValidateEndpointURL, "", false},
+		{"endpoint https", ValidateEndpointURL, "https://auth.example.com/token", false},
+		{"endpoint relative", ValidateEndpointURL, "/oauth/token", true},
+	}
+	for _, tt := range tests {
+		if err := tt.validate(tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("%s: validating %q returned %v, want error %v", tt.name, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+// TestFieldErrors tests that invalid fields are reported by the Query tab and marked inline.
+func TestFieldErrors(t *testing.T) {
+	q := NewQueryTab()
+	q.ParamsInput.SetParams(map[string]string{"page=2": ""})
+	q.HeadersInput.SetHeaders(map[string]string{"Accept": "text/plain"})
+
+	errs := q.FieldErrors()
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Field, "Params row") {
+		t.Fatalf("got %v, want one error for the parameter name", errs)
+	}
+	if view := q.ParamsInput.View(); !strings.Contains(view, "put the value in the Value column") {
+		t.Errorf("params view does not show the error:\n%s", view)
+	}
+
+	q.ParamsInput.ClearParams()
+	if errs := q.FieldErrors(); len(errs) != 0 {
+		t.Errorf("got %v after clearing the params, want none", errs)
+	}
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// hostOverride sends a request to one host while connecting to another, like curl
+// --connect-to: the Host header and TLS server name (SNI) use the name, while the connection
+// goes to the address in the URL. This is how load balancers and CDN edges are tested before
+// DNS points at them.
+type hostOverride struct {
+	name   string // Host the request is addressed to, e.g. "api.example.com" or "api.example.com:8443"
+	dial   string // Address the rewritten URL dials, name with the URL's port if it has none
+	target string // Address actually connected to, from the URL, e.g. "10.0.0.5:443"
+}
+
+// defaultPorts are the ports implied by URL schemes without an explicit port.
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// splitHostOverride removes a Host header from headers and, if it names a host other than
+// the URL's, returns the override to send with. The returned headers are a copy when changed.
+func splitHostOverride(requestURL string, headers map[string]string) (map[string]string, *hostOverride, error) {
+	name := ""
+	remaining := make(map[string]string, len(headers))
+	for key, value := range headers {
+		if http.CanonicalHeaderKey(key) == "Host" {
+			name = strings.TrimSpace(value)
+			continue
+		}
+		remaining[key] = value
+	}
+	if name == "" {
+		return headers, nil, nil
+	}
+
+	target, err := url.Parse(requestURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if strings.EqualFold(name, target.Host) {
+		return remaining, nil, nil
+	}
+
+	port := target.Port()
+	if port == "" {
+		port = defaultPorts[target.Scheme]
+	}
+	dial := name
+	if _, _, err := net.SplitHostPort(name); err != nil {
+		dial = net.JoinHostPort(name, port)
+	}
+	return remaining, &hostOverride{name: name, dial: dial, target: net.JoinHostPort(target.Hostname(), port)}, nil
+}
+
+// rewriteURL returns requestURL addressed to the override's name and the URL's port, so that
+// Go sends the name as the TLS server name. The Host header is set from the name separately,
+// so it is sent exactly as entered.
+func (o *hostOverride) rewriteURL(requestURL string) (string, error) {
+	target, err := url.Parse(requestURL)
+	if err != nil {
+		return "", err
+	}
+	target.Host = o.dial
+	return target.String(), nil
+}
+
+// serverName returns the name sent as the TLS server name (SNI): the name without its port.
+func (o *hostOverride) serverName() string {
+	host, _, err := net.SplitHostPort(o.dial)
+	if err != nil {
+		return o.name
+	}
+	return host
+}
+
+// apply makes transport connect to the override's target whenever it dials the name.
+// Connections to other hosts, e.g. after a redirect, are left alone.
+func (o *hostOverride) apply(transport *http.Transport) {
+	dial := transport.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if strings.EqualFold(addr, o.dial) {
+			addr = o.target
+		}
+		return dial(ctx, network, addr)
+	}
+}
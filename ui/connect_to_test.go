@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/fixtures"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestSplitHostOverride tests which Host headers override the URL's host and where they connect.
+func TestSplitHostOverride(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		host       string
+		wantDial   string
+		wantTarget string
+	}{
+		{"Default HTTPS port", "https://10.0.0.5/items", "api.example.com", "api.example.com:443", "10.0.0.5:443"},
+		{"URL port", "http://10.0.0.5:8080/", "api.example.com", "api.example.com:8080", "10.0.0.5:8080"},
+		{"Host with port", "https://10.0.0.5/", "api.example.com:8443", "api.example.com:8443", "10.0.0.5:443"},
+		{"Same host", "https://api.example.com/", "API.example.com", "", ""},
+		{"No Host header", "https://api.example.com/", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{"Accept": "*/*"}
+			if tt.host != "" {
+				headers["host"] = tt.host
+			}
+			remaining, override, err := splitHostOverride(tt.url, headers)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(remaining) != 1 || remaining["Accept"] == "" {
+				t.Errorf("headers = %v, want only Accept", remaining)
+			}
+			if tt.wantDial == "" {
+				if override != nil {
+					t.Errorf("got override %+v, want none", override)
+				}
+				return
+			}
+			if override == nil || override.dial != tt.wantDial || override.target != tt.wantTarget {
+				t.Errorf("got override %+v, want dial %s and target %s", override, tt.wantDial, tt.wantTarget)
+			}
+		})
+	}
+}
+
+// TestSendRequestHostOverride tests that the request reaches the URL's server with the Host
+// header set.
+func TestSendRequestHostOverride(t *testing.T) {
+	server := fixtures.NewServer(t)
+
+	headers := map[string]string{"Host": "api.example.test"}
+	resp, err := sendRequest(context.Background(), "GET", server.URL+"/echo", headers, "", proxyRoute{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var echo fixtures.Echo
+	if err := json.Unmarshal(resp.Body, &echo); err != nil {
+		t.Fatalf("decoding echo: %v", err)
+	}
+	if echo.Path != "/echo" {
+		t.Errorf("server got path %q, want /echo", echo.Path)
+	}
+	if last, _ := server.LastRequest(); last.Host != "api.example.test" {
+		t.Errorf("server got Host %q, want api.example.test", last.Host)
+	}
+
+	target, _ := url.Parse(server.URL)
+	preview, err := renderRequestPreview(preparedRequest{
+		method:   "GET",
+		rawURL:   server.URL,
+		finalURL: server.URL,
+		headers:  headers,
+		sources:  map[string]string{"Host": "Headers tab"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Host: api.example.test  # Headers tab, connecting to " + target.Host; !strings.Contains(ansi.Strip(preview), want) {
+		t.Errorf("preview does not contain %q:\n%s", want, ansi.Strip(preview))
+	}
+}
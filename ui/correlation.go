@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/request"
+	"github.com/RAshkettle/LazyPost/ui/styles"
+)
+
+// newRequestID returns a random version 4 UUID to identify one send of a request.
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])         // Never fails, see crypto/rand.Read
+	b[6] = b[6]&0x0f | 0x40 // Version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// addRequestID sets the correlation header to a fresh ID unless the request already sets it,
+// and returns the ID the request is sent with. An empty header name disables correlation IDs.
+func addRequestID(header string, headers, sources map[string]string) string {
+	if header == "" {
+		return ""
+	}
+	header = http.CanonicalHeaderKey(header)
+	if id, ok := headers[header]; ok {
+		return id
+	}
+	id := newRequestID()
+	headers[header] = id
+	sources[header] = "correlation ID (new for every send)"
+	return id
+}
+
+// formatRequestID renders the Headers result line reporting the correlation ID sent
+// and whether the server echoed it in the same header.
+func formatRequestID(resp response, prepared preparedRequest) string {
+	if prepared.requestID == "" {
+		return ""
+	}
+	echo := "not echoed by the server"
+	if requestIDEchoed(resp.Header, prepared.requestIDHeader, prepared.requestID) {
+		echo = "echoed by the server"
+	}
+	return fmt.Sprintf("%s %s (%s)\n", styles.HeaderNameStyle.Render("Request ID:"), prepared.requestID, echo)
+}
+
+// requestIDEchoed reports whether header holds id among its values.
+func requestIDEchoed(h http.Header, header, id string) bool {
+	for _, value := range h.Values(header) {
+		if strings.TrimSpace(value) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// shortRequestID returns the first group of a UUID, enough to tell sends apart in a list.
+func shortRequestID(id string) string {
+	short, _, _ := strings.Cut(id, "-")
+	return short
+}
+
+// rememberRequestID records id as the correlation ID of the last send of r, so the recent
+// requests list can be matched with server logs.
+func (a *App) rememberRequestID(r request.Request, id string) {
+	if id == "" {
+		return
+	}
+	if a.recentRequestIDs == nil {
+		a.recentRequestIDs = make(map[string]string)
+	}
+	a.recentRequestIDs[requestKey(r)] = id
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestAddRequestID tests that a fresh UUID is added per send unless the request sets the header.
+func TestAddRequestID(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	headers, sources := map[string]string{}, map[string]string{}
+	first := addRequestID("x-request-id", headers, sources)
+	if !uuid.MatchString(first) || headers["X-Request-Id"] != first {
+		t.Fatalf("got ID %q and headers %v, want a v4 UUID under X-Request-Id", first, headers)
+	}
+	if second := addRequestID("X-Request-ID", map[string]string{}, map[string]string{}); second == first {
+		t.Errorf("two sends got the same ID %q", first)
+	}
+
+	headers = map[string]string{"X-Request-Id": "mine"}
+	if id := addRequestID("X-Request-ID", headers, map[string]string{}); id != "mine" || headers["X-Request-Id"] != "mine" {
+		t.Errorf("got ID %q, want the entered header value kept", id)
+	}
+
+	if id := addRequestID("", headers, sources); id != "" {
+		t.Errorf("got ID %q with correlation IDs disabled, want none", id)
+	}
+}
+
+// TestFormatRequestID tests reporting whether the server echoed the correlation ID.
+func TestFormatRequestID(t *testing.T) {
+	prepared := preparedRequest{requestIDHeader: "X-Request-Id", requestID: "abc"}
+
+	echoed := response{Header: http.Header{"X-Request-Id": {"abc"}}}
+	if got := formatRequestID(echoed, prepared); !strings.Contains(got, "abc (echoed by the server)") {
+		t.Errorf("got %q, want the ID reported as echoed", got)
+	}
+	other := response{Header: http.Header{"X-Request-Id": {"xyz"}}}
+	if got := formatRequestID(other, prepared); !strings.Contains(got, "not echoed") {
+		t.Errorf("got %q, want the ID reported as not echoed", got)
+	}
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/discovery"
+	"github.com/RAshkettle/LazyPost/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pickerMode identifies what a choice in the App's picker is used for.
+type pickerMode int
+
+const (
+	pickerNone    pickerMode = iota
+	pickerService            // Choosing a discovered local service as the URL target
+	pickerRecent             // Switching to a recently used request
+	pickerLocale             // Choosing the locale to request responses in
+	pickerPin                // Pinning the request to an environment
+)
+
+// handleDiscoverServices opens the picker and scans for local services in the background.
+func (a *App) handleDiscoverServices() tea.Cmd {
+	a.picker.Open("Local services", "Scanning local ports and Docker containers...")
+	a.pickerMode = pickerService
+
+	return a.jobs.start(job{name: "Service discovery", run: func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		defer cancel()
+		return ServicesDiscoveredMsg{Services: discovery.Discover(ctx, 300*time.Millisecond)}, nil
+	}})
+}
+
+// handleServicesDiscoveredMsg lists the discovered services in the picker,
+// unless the picker was closed while the scan was running.
+func (a *App) handleServicesDiscoveredMsg(msg ServicesDiscoveredMsg) {
+	if !a.picker.Visible || a.pickerMode != pickerService {
+		return
+	}
+
+	items := make([]components.PickerItem, 0, len(msg.Services))
+	for _, service := range msg.Services {
+		items = append(items, components.PickerItem{
+			Label: fmt.Sprintf("%-24s %s", service.Name, service.URL),
+			Value: service.URL,
+		})
+	}
+	a.picker.SetItems(items, "No local services found.")
+}
+
+// handlePickerChoice applies the item chosen in the picker.
+func (a *App) handlePickerChoice(item components.PickerItem) {
+	switch a.pickerMode {
+	case pickerService:
+		a.urlInput.SetText(retargetURL(a.urlInput.GetText(), item.Value))
+		a.setFocus(focusURL)
+	case pickerRecent:
+		a.loadRecentRequest(item.Value)
+	case pickerLocale:
+		a.applyLocale(item.Value)
+	case pickerPin:
+		a.pinEnvironment(item.Value)
+	}
+	a.pickerMode = pickerNone
+}
+
+// retargetURL points current at the scheme and host of base, keeping its path, query and fragment.
+// A path in base is used as a prefix, e.g. retargeting "https://a.com/users" to "https://b.com/v2"
+// gives "https://b.com/v2/users". If current is empty or cannot be parsed, base is returned.
+func retargetURL(current, base string) string {
+	currentURL, err := url.Parse(current)
+	if err != nil || currentURL.Host == "" {
+		return base
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return base
+	}
+
+	currentURL.Scheme = baseURL.Scheme
+	currentURL.Host = baseURL.Host
+	if prefix := strings.TrimSuffix(baseURL.Path, "/"); prefix != "" {
+		currentURL.Path = prefix + currentURL.Path
+		currentURL.RawPath = ""
+	}
+	return currentURL.String()
+}
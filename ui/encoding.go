@@ -0,0 +1,38 @@
+package ui
+
+import "fmt"
+
+// identityEncoding is the Accept-Encoding value that asks for the body without compression.
+const identityEncoding = "identity"
+
+// addIdentityEncoding asks the server for an uncompressed body by adding
+// "Accept-Encoding: identity", unless an Accept-Encoding header is already set.
+// Either way Go no longer decompresses the response, so whatever encoding the
+// server still chooses is shown as received.
+func addIdentityEncoding(headers, sources map[string]string) {
+	if _, ok := headers["Accept-Encoding"]; ok {
+		return
+	}
+	headers["Accept-Encoding"] = identityEncoding
+	sources["Accept-Encoding"] = "raw encoding mode"
+}
+
+// responseEncoding describes the Content-Encoding of a response received in raw
+// encoding mode, whose body is shown exactly as it arrived.
+func responseEncoding(contentEncoding string) string {
+	if contentEncoding == "" {
+		contentEncoding = identityEncoding
+	}
+	return contentEncoding + " (shown as received, not decompressed)"
+}
+
+// handleToggleRawEncoding switches between Go's automatic gzip handling and requesting
+// identity encoding with the body shown exactly as the server sends it.
+func (a *App) handleToggleRawEncoding() {
+	a.rawEncoding = !a.rawEncoding
+	if a.rawEncoding {
+		a.toast.Show(fmt.Sprintf("Accept-Encoding: %s is sent and responses are not decompressed", identityEncoding))
+	} else {
+		a.toast.Show("Responses are requested with gzip and decompressed automatically")
+	}
+}
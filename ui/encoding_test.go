@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/fixtures"
+)
+
+// TestRawEncoding tests that in raw encoding mode identity encoding is requested and a
+// gzip body sent anyway is returned as received instead of being decompressed.
+func TestRawEncoding(t *testing.T) {
+	server := fixtures.NewServer(t)
+
+	headers, sources := map[string]string{}, map[string]string{}
+	addIdentityEncoding(headers, sources)
+	resp, err := sendRequest(context.Background(), "GET", server.URL+"/gzip", headers, "", proxyRoute{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last, _ := server.LastRequest(); last.Header.Get("Accept-Encoding") != "identity" {
+		t.Errorf("Accept-Encoding = %q, want identity", last.Header.Get("Accept-Encoding"))
+	}
+	if resp.Decompressed || bytes.Equal(resp.Body, []byte(fixtures.GzipBody)) {
+		t.Errorf("body was decompressed: %q", resp.Body)
+	}
+
+	// The default mode decompresses transparently
+	resp, err = sendRequest(context.Background(), "GET", server.URL+"/gzip", nil, "", proxyRoute{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Decompressed || string(resp.Body) != fixtures.GzipBody {
+		t.Errorf("got body %q (decompressed %v), want %q", resp.Body, resp.Decompressed, fixtures.GzipBody)
+	}
+}
+
+// TestAddIdentityEncodingKeepsHeader tests that an Accept-Encoding entered by the user is kept.
+func TestAddIdentityEncodingKeepsHeader(t *testing.T) {
+	headers := map[string]string{"Accept-Encoding": "br"}
+	sources := map[string]string{"Accept-Encoding": "Headers tab"}
+	addIdentityEncoding(headers, sources)
+	if headers["Accept-Encoding"] != "br" || sources["Accept-Encoding"] != "Headers tab" {
+		t.Errorf("got %q from %q, want br from the Headers tab", headers["Accept-Encoding"], sources["Accept-Encoding"])
+	}
+}
@@ -0,0 +1,19 @@
+package ui
+
+import "context"
+
+// requestEngine executes requests prepared from the form. The App sends through httpEngine;
+// tests can substitute another engine to drive request handling without a network, or
+// point the App at a fixtures server to exercise the real one deterministically.
+type requestEngine interface {
+	// send sends a request along route and reads the whole response, as sendRequest does.
+	send(ctx context.Context, method, requestURL string, headers map[string]string, body string, route proxyRoute) (response, error)
+}
+
+// httpEngine sends requests over the network with net/http.
+type httpEngine struct{}
+
+// send sends the request with sendRequest.
+func (httpEngine) send(ctx context.Context, method, requestURL string, headers map[string]string, body string, route proxyRoute) (response, error) {
+	return sendRequest(ctx, method, requestURL, headers, body, route)
+}
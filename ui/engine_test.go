@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/fixtures"
+)
+
+// fakeEngine records the request it is asked to send and returns a canned response.
+type fakeEngine struct {
+	method  string            // Method of the last request sent
+	url     string            // URL of the last request sent
+	headers map[string]string // Headers of the last request sent
+	resp    response          // Response returned for every request
+}
+
+// send records the request and returns the canned response.
+func (f *fakeEngine) send(_ context.Context, method, requestURL string, headers map[string]string, _ string, _ proxyRoute) (response, error) {
+	f.method, f.url, f.headers = method, requestURL, headers
+	return f.resp, nil
+}
+
+// TestPreparedRequestUsesEngine tests that a prepared request is sent through the App's
+// engine, so request handling can be tested without a network.
+func TestPreparedRequestUsesEngine(t *testing.T) {
+	engine := &fakeEngine{resp: response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"text/plain"}},
+		Body:       []byte("faked"),
+	}}
+	app := NewApp(config.Config{})
+	app.engine = engine
+
+	prepared, err := app.prepareRequest("http://example.invalid/items")
+	if err != nil {
+		t.Fatalf("prepareRequest() error = %v", err)
+	}
+	msg, err := prepared.send(context.Background(), func(string) {})
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	complete, ok := msg.(RequestCompleteMsg)
+	if !ok {
+		t.Fatalf("send() = %T, want RequestCompleteMsg", msg)
+	}
+	if string(complete.Body) != "faked" || complete.ContentType != "text/plain" {
+		t.Errorf("got body %q of type %q, want the faked response", complete.Body, complete.ContentType)
+	}
+	if engine.method != "GET" || engine.url != "http://example.invalid/items" {
+		t.Errorf("engine got %s %s, want GET http://example.invalid/items", engine.method, engine.url)
+	}
+}
+
+// TestPreparedRequestAgainstFixtures tests a request sent by the real engine end to end:
+// the correlation ID reaches the server and its echo is reported.
+func TestPreparedRequestAgainstFixtures(t *testing.T) {
+	server := fixtures.NewServer(t)
+	app := NewApp(config.Config{RequestIDHeader: "X-Request-ID"})
+
+	prepared, err := app.prepareRequest(server.URL + "/echo")
+	if err != nil {
+		t.Fatalf("prepareRequest() error = %v", err)
+	}
+	msg, err := prepared.send(context.Background(), func(string) {})
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	complete := msg.(RequestCompleteMsg)
+
+	var echo fixtures.Echo
+	if err := json.Unmarshal(complete.Body, &echo); err != nil {
+		t.Fatalf("decoding echo: %v", err)
+	}
+	if got := echo.Header["X-Request-Id"]; len(got) != 1 || got[0] != prepared.requestID {
+		t.Errorf("server got X-Request-ID %q, want %q", got, prepared.requestID)
+	}
+	if !strings.Contains(complete.Headers, "(echoed by the server)") {
+		t.Errorf("headers do not report the echo:\n%s", complete.Headers)
+	}
+}
+
+// TestPreparedRequestKeepsVaultPlaceholders tests that Vault secrets are resolved only
+// into the request that is sent, and never written back into the prepared request that
+// the preview, error report and session recording read.
+func TestPreparedRequestKeepsVaultPlaceholders(t *testing.T) {
+	vaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"token": "abc"}}`))
+	}))
+	t.Cleanup(vaultServer.Close)
+	t.Setenv("VAULT_TOKEN", "test-token")
+
+	engine := &fakeEngine{resp: response{Status: "200 OK", StatusCode: http.StatusOK, Header: http.Header{}}}
+	app := NewApp(config.Config{Vault: config.Vault{Address: vaultServer.URL}})
+	app.engine = engine
+	app.tabContainer.GetQueryTab().HeadersInput.SetHeaders(map[string]string{"Authorization": "Bearer {{vault:kv/api#token}}"})
+
+	prepared, err := app.prepareRequest("http://example.invalid/items")
+	if err != nil {
+		t.Fatalf("prepareRequest() error = %v", err)
+	}
+	if _, err := prepared.send(context.Background(), func(string) {}); err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	if got := engine.headers["Authorization"]; got != "Bearer abc" {
+		t.Errorf("engine got Authorization %q, want the resolved secret", got)
+	}
+	if got := prepared.headers["Authorization"]; got != "Bearer {{vault:kv/api#token}}" {
+		t.Errorf("prepared request holds Authorization %q after send, want the placeholder", got)
+	}
+}
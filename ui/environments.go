@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/RAshkettle/LazyPost/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleEditEnvironments opens the environment editor on the current environments.
+func (a *App) handleEditEnvironments() {
+	a.environmentEditor.Open(a.environments)
+}
+
+// handleEnvironmentEditorKey passes a key press to the environment editor. Edits apply to
+// substitution right away and are saved to the environments file when the editor closes.
+func (a *App) handleEnvironmentEditorKey(msg tea.KeyMsg) tea.Cmd {
+	closed, cmd := a.environmentEditor.Update(msg)
+	a.environments = a.environmentEditor.Store()
+	if !closed {
+		return cmd
+	}
+
+	if a.environmentsPath == "" {
+		a.toast.Show("Environments apply to this session only: no config directory to save them in")
+		return nil
+	}
+	if err := a.environments.Save(a.environmentsPath); err != nil {
+		a.toast.Show(fmt.Sprintf("Error saving environments: %v", err))
+	}
+	a.watch.sync(a.environmentsPath) // Saved here, so watch mode does not resend
+	return nil
+}
+
+// handlePinEnvironment opens the picker with the environments the request can be pinned to.
+func (a *App) handlePinEnvironment() {
+	if len(a.environments.Environments) == 0 && a.pinnedEnvironment == "" {
+		a.toast.Show("No environments to pin to: add one with Alt+V")
+		return
+	}
+
+	a.picker.Open("Pin request to environment", "")
+	a.pickerMode = pickerPin
+
+	items := make([]components.PickerItem, 0, len(a.environments.Environments)+1)
+	for _, e := range a.environments.Environments {
+		label := e.Name
+		if e.Name == a.pinnedEnvironment {
+			label += " (pinned)"
+		}
+		items = append(items, components.PickerItem{Label: label, Value: e.Name})
+	}
+	items = append(items, components.PickerItem{Label: "Unpin (use the selected environment)", Value: ""})
+	a.picker.SetItems(items, "")
+}
+
+// pinEnvironment pins the current request to the environment called name, or unpins it if
+// name is empty. A pinned request is saved with the environment name when exported.
+func (a *App) pinEnvironment(name string) {
+	a.pinnedEnvironment = name
+	if name == "" {
+		a.toast.Show("Request unpinned: it uses the selected environment")
+		return
+	}
+	a.toast.Show(fmt.Sprintf("Request pinned to %s: it is always sent with its variables", name))
+}
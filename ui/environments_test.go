@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/env"
+)
+
+// TestPinnedEnvironment checks that a pinned environment overrides the selected one and
+// that a request pinned to a missing environment is not sent.
+func TestPinnedEnvironment(t *testing.T) {
+	app := NewApp(config.Config{})
+	app.SetEnvironments(env.Store{Active: "dev", Environments: []env.Environment{
+		{Name: "dev", Variables: []env.Variable{{Name: "host", Value: "dev.example.com"}}},
+		{Name: "prod", Variables: []env.Variable{{Name: "host", Value: "api.example.com"}}},
+	}}, "")
+	app.urlInput.SetText("https://{{host}}/users")
+
+	if got := app.requestURL(); got != "https://dev.example.com/users" {
+		t.Errorf("requestURL() = %q, want the selected environment's host", got)
+	}
+
+	app.pinEnvironment("prod")
+	if got := app.requestURL(); got != "https://api.example.com/users" {
+		t.Errorf("requestURL() = %q, want the pinned environment's host", got)
+	}
+	if got := app.snapshotRequest().Environment; got != "prod" {
+		t.Errorf("snapshot Environment = %q, want prod", got)
+	}
+
+	app.pinnedEnvironment = "staging"
+	if app.checkVariables() {
+		t.Error("a request pinned to a missing environment should not be sent")
+	}
+}
+
+// TestParamNamesExpanded checks that placeholders in parameter names are expanded like those
+// in values, since both are linted.
+func TestParamNamesExpanded(t *testing.T) {
+	app := NewApp(config.Config{})
+	app.SetEnvironments(env.Store{Active: "dev", Environments: []env.Environment{
+		{Name: "dev", Variables: []env.Variable{{Name: "field", Value: "user_id"}, {Name: "id", Value: "42"}}},
+	}}, "")
+	app.tabContainer.GetQueryTab().ParamsInput.SetParams(map[string]string{"{{field}}": "{{id}}"})
+
+	prepared, err := app.prepareRequest("https://example.com/users")
+	if err != nil {
+		t.Fatalf("prepareRequest() error = %v", err)
+	}
+	if prepared.finalURL != "https://example.com/users?user_id=42" {
+		t.Errorf("finalURL = %q, want the parameter name and value expanded", prepared.finalURL)
+	}
+}
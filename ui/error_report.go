@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/redact"
+	"github.com/RAshkettle/LazyPost/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// requestJobName is the name of the job that sends the request in the form.
+const requestJobName = "Request"
+
+// sentRequest is a request that was sent, kept so that a failure can be reported with its context.
+type sentRequest struct {
+	prepared    preparedRequest // The request as it was sent
+	environment string          // Name of the environment it was sent with, empty if none
+	started     time.Time       // When it was sent
+}
+
+// errorReport is the context of a failed request, exported to attach to bug reports.
+type errorReport struct {
+	sentRequest
+	failed time.Time // When the request failed
+	err    error     // Why it failed
+}
+
+// recordFailure keeps the context of a request that failed for handleExportErrorReport and
+// reports whether it did. Failures of other jobs, such as comparisons, are not recorded.
+func (a *App) recordFailure(msg jobDoneMsg) bool {
+	if msg.sent == nil {
+		return false
+	}
+	a.lastFailure = &errorReport{sentRequest: *msg.sent, failed: time.Now(), err: msg.err}
+	return true
+}
+
+// handleExportErrorReport saves the context of the last failed request to a timestamped
+// text file in the working directory. Secrets are always redacted, whatever the redaction
+// settings for copies, since the report is meant to be attached to a bug report.
+func (a *App) handleExportErrorReport() tea.Cmd {
+	if a.lastFailure == nil {
+		a.toast.Show("No failed request to report")
+		return nil
+	}
+
+	report, err := formatErrorReport(*a.lastFailure, a.privacyRedactor)
+	if err != nil {
+		a.toast.Show(fmt.Sprintf("Error building error report: %v", err))
+		return nil
+	}
+	name := "lazypost-error-" + a.lastFailure.failed.Format("20060102-150405") + ".txt"
+
+	return a.jobs.start(job{name: "Error report export", run: func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		if err := os.WriteFile(name, []byte(report), 0o600); err != nil {
+			return components.ShowToastMsg{Message: fmt.Sprintf("Error exporting error report: %v", err)}, nil
+		}
+		return components.ShowToastMsg{Message: fmt.Sprintf("Exported error report to %s", name)}, nil
+	}})
+}
+
+// formatErrorReport renders report as plain text: the failure with its guidance and error
+// chain, the timing, the environment and the request preview, with secrets redacted. Values
+// of secret environment variables are masked wherever they were expanded, e.g. into the URL
+// repeated by the error chain.
+func formatErrorReport(report errorReport, redactor *redact.Redactor) (string, error) {
+	preview, err := renderRequestPreview(report.prepared)
+	if err != nil {
+		return "", err
+	}
+
+	kind := failureUnknown
+	var reqErr *requestError
+	if errors.As(report.err, &reqErr) {
+		kind = reqErr.kind
+	}
+	environment := report.environment
+	if environment == "" {
+		environment = "(none)"
+	}
+
+	var text strings.Builder
+	text.WriteString("LazyPost error report\n\n")
+	text.WriteString(fmt.Sprintf("Failure:     %s\n", kind.title()))
+	text.WriteString(fmt.Sprintf("Sent:        %s\n", report.started.Format(time.RFC3339Nano)))
+	text.WriteString(fmt.Sprintf("Failed:      %s (after %s)\n", report.failed.Format(time.RFC3339Nano), report.failed.Sub(report.started).Round(time.Millisecond)))
+	text.WriteString(fmt.Sprintf("Environment: %s\n", environment))
+	text.WriteString(fmt.Sprintf("Platform:    %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH))
+
+	text.WriteString("\nWhat to check:\n")
+	for _, line := range kind.guidance() {
+		text.WriteString("  • " + line + "\n")
+	}
+	text.WriteString("\nError chain:\n")
+	for i, message := range errorChain(report.err) {
+		text.WriteString(fmt.Sprintf("  %d. %s\n", i+1, message))
+	}
+
+	text.WriteString("\nRequest:\n")
+	text.WriteString(ansi.Strip(preview))
+	return redactor.Text(maskSecretValues(text.String(), report.prepared.secrets, redact.Placeholder)), nil
+}
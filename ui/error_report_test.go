@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/redact"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestFormatErrorReport tests that the report holds the failure, timing, environment and
+// request, with the credentials and the values of secret variables redacted.
+func TestFormatErrorReport(t *testing.T) {
+	redactor, err := redact.New(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	dnsErr := &net.DNSError{Err: "no such host", Name: "api.example.invalid"}
+
+	report, err := formatErrorReport(errorReport{
+		sentRequest: sentRequest{
+			prepared: preparedRequest{
+				method:   "POST",
+				rawURL:   "https://api.example.invalid/login?key=k3y-value",
+				finalURL: "https://api.example.invalid/login?key=k3y-value",
+				headers:  map[string]string{"Authorization": "Bearer s3cr3t"},
+				sources:  map[string]string{"Authorization": "Auth tab"},
+				body:     `{"password": "hunter2", "pin": "p1n-value"}`,
+				secrets:  map[string]string{"key": "k3y-value", "pin": "p1n-value"},
+			},
+			environment: "staging",
+			started:     started,
+		},
+		failed: started.Add(1500 * time.Millisecond),
+		err:    classifyError(fmt.Errorf("Post %q: %w", "https://api.example.invalid/login?key=k3y-value", dnsErr)),
+	}, redactor)
+	if err != nil {
+		t.Fatalf("formatErrorReport() error = %v", err)
+	}
+
+	for _, expected := range []string{
+		"Failure:     Host not found",
+		"(after 1.5s)",
+		"Environment: staging",
+		"lookup api.example.invalid: no such host",
+		"POST /login?key=" + redact.Placeholder + " HTTP/1.1",
+		"Authorization: " + redact.Placeholder,
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("report does not contain %q:\n%s", expected, report)
+		}
+	}
+	for _, secret := range []string{"s3cr3t", "hunter2", "k3y-value", "p1n-value", "\x1b["} {
+		if strings.Contains(report, secret) {
+			t.Errorf("report contains %q:\n%s", secret, report)
+		}
+	}
+}
+
+// TestFormatErrorReportQueryParameters tests that credentials passed as query parameters are
+// redacted in the request line, the query parameters and the error chain.
+func TestFormatErrorReportQueryParameters(t *testing.T) {
+	redactor, err := redact.New(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	finalURL := "https://api.example.invalid/items?api_key=k3y-value&page=2"
+	report, err := formatErrorReport(errorReport{
+		sentRequest: sentRequest{prepared: preparedRequest{
+			method:   "GET",
+			rawURL:   finalURL,
+			finalURL: finalURL,
+		}},
+		err: classifyError(fmt.Errorf("Get %q: %w", finalURL, &net.DNSError{Err: "no such host", Name: "api.example.invalid"})),
+	}, redactor)
+	if err != nil {
+		t.Fatalf("formatErrorReport() error = %v", err)
+	}
+
+	if strings.Contains(report, "k3y-value") {
+		t.Errorf("report contains the api_key value:\n%s", report)
+	}
+	if !strings.Contains(report, "GET /items?api_key="+redact.Placeholder+"&page=2 HTTP/1.1") {
+		t.Errorf("report does not keep the other query parameters:\n%s", report)
+	}
+}
+
+// TestRecordFailureKeepsFailedRequest tests that the error report describes the request the
+// failed job sent, not the form as it is when the failure arrives.
+func TestRecordFailureKeepsFailedRequest(t *testing.T) {
+	app := NewApp(config.Config{})
+	failed := &sentRequest{prepared: preparedRequest{method: "GET", finalURL: "https://failed.example.invalid/"}}
+	cmd := app.jobs.start(job{name: requestJobName, sent: failed, run: func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		return nil, classifyError(&net.DNSError{Err: "no such host", Name: "failed.example.invalid"})
+	}})
+	app.urlInput.SetText("https://edited.example.invalid/")
+
+	app.handleJobDoneMsg(cmd().(jobDoneMsg))
+	if app.lastFailure == nil || app.lastFailure.prepared.finalURL != failed.prepared.finalURL {
+		t.Fatalf("lastFailure = %+v, want the failed request", app.lastFailure)
+	}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/RAshkettle/LazyPost/config"
+)
+
+// hostRuleHeaders returns the headers contributed by the host rules that match rawURL,
+// along with the host patterns of the rules that fired. Header names are canonicalized
+// so they merge cleanly with headers from the UI. Later rules override earlier ones.
+// sources records, for each header, which rule it came from.
+func hostRuleHeaders(rules []config.HostRule, rawURL string, sources map[string]string) (map[string]string, []string) {
+	headers := make(map[string]string)
+	var fired []string
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return headers, nil
+	}
+
+	for _, rule := range rules {
+		if !rule.Matches(parsedURL.Hostname()) {
+			continue
+		}
+		source := "host rule " + rule.Host
+		ruleHeaders := make(map[string]string, len(rule.Headers)+1)
+		for name, value := range rule.Headers {
+			ruleHeaders[name] = value
+		}
+		if rule.BearerToken != "" {
+			ruleHeaders["Authorization"] = "Bearer " + rule.BearerToken
+		}
+		mergeHeaders(headers, ruleHeaders, sources, source)
+		fired = append(fired, rule.Host)
+	}
+	return headers, fired
+}
+
+// mergeHeaders copies src into dst, canonicalizing names so that the same header
+// entered with different casing is overridden rather than sent twice.
+// sources records source as the origin of every copied header, noting what it overrode.
+func mergeHeaders(dst, src map[string]string, sources map[string]string, source string) {
+	for name, value := range src {
+		name = http.CanonicalHeaderKey(name)
+		if previous, ok := sources[name]; ok && previous != source {
+			sources[name] = source + " (overrides " + previous + ")"
+		} else {
+			sources[name] = source
+		}
+		dst[name] = value
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+)
+
+// interimResponse is an informational (1xx) response received before the final response,
+// e.g. 103 Early Hints or 102 Processing.
+type interimResponse struct {
+	StatusCode int           // Status code, e.g. 103
+	Header     http.Header   // Headers of the interim response, e.g. Link for Early Hints
+	Elapsed    time.Duration // Time from sending the request until it arrived
+}
+
+// formatInterimResponses renders the informational responses received before the final one,
+// in the order they arrived, for the Headers result view.
+func formatInterimResponses(interim []interimResponse) string {
+	if len(interim) == 0 {
+		return ""
+	}
+
+	var text strings.Builder
+	text.WriteString(styles.HeaderNameStyle.Render("Informational responses:") + "\n")
+	for _, r := range interim {
+		text.WriteString(fmt.Sprintf("  %d %s (after %s)\n", r.StatusCode, http.StatusText(r.StatusCode), r.Elapsed.Round(time.Millisecond)))
+		for _, name := range sortedHeaderNames(r.Header) {
+			for _, value := range r.Header[name] {
+				text.WriteString(fmt.Sprintf("    %s %s\n", styles.HeaderNameStyle.Render(name+":"), value))
+			}
+		}
+	}
+	return text.String()
+}
+
+// sortedHeaderNames returns the names in h in sorted order.
+func sortedHeaderNames(h http.Header) []string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/fixtures"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestSendRequestInformational tests that 1xx responses are kept in order and shown
+// above the final response's headers.
+func TestSendRequestInformational(t *testing.T) {
+	server := fixtures.NewServer(t)
+
+	resp, err := sendRequest(context.Background(), "GET", server.URL+"/informational", nil, "", proxyRoute{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 || len(resp.Interim) != 2 {
+		t.Fatalf("got status %d with %d interim responses, want 200 after 2", resp.StatusCode, len(resp.Interim))
+	}
+	if resp.Interim[0].StatusCode != 102 || resp.Interim[1].StatusCode != 103 {
+		t.Errorf("interim status codes = %d, %d, want 102, 103", resp.Interim[0].StatusCode, resp.Interim[1].StatusCode)
+	}
+	if resp.Header.Get("Link") != "" {
+		t.Errorf("final response has Link %q, want it only on the Early Hints", resp.Header.Get("Link"))
+	}
+
+	formatted := ansi.Strip(formatResponseHeaders(resp, preparedRequest{}))
+	for _, expected := range []string{
+		"Informational responses:\n  102 Processing (after ",
+		"  103 Early Hints (after ",
+		"    Link: </style.css>; rel=preload; as=style\n",
+	} {
+		if !strings.Contains(formatted, expected) {
+			t.Errorf("headers view does not contain %q:\n%s", expected, formatted)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+)
+
+// digestAlgorithms maps the algorithm names used in Digest, Content-Digest and Repr-Digest
+// headers to their hash functions.
+var digestAlgorithms = map[string]func() hash.Hash{
+	"md5":     md5.New,
+	"sha-256": sha256.New,
+	"sha-512": sha512.New,
+}
+
+// digestCheck is the result of verifying one digest sent by the server against the body.
+type digestCheck struct {
+	header    string // Header the digest came from, e.g. "Content-MD5"
+	algorithm string // Hash algorithm, e.g. "sha-256"
+	status    string // "matches", "MISMATCH" or why it could not be verified
+}
+
+// bodyHashes returns the hex SHA-256 and MD5 of body.
+func bodyHashes(body []byte) (string, string) {
+	sha := sha256.Sum256(body)
+	sum := md5.Sum(body)
+	return hex.EncodeToString(sha[:]), hex.EncodeToString(sum[:])
+}
+
+// verifyDigests checks the Content-MD5, Digest, Content-Digest and Repr-Digest headers
+// of a response against its body. All of them cover the body with its content coding
+// applied; Repr-Digest too, since RFC 9530 counts the coding as part of the representation.
+// So if the transport decompressed the body, none of them can be verified.
+func verifyDigests(header http.Header, body []byte, decompressed bool) []digestCheck {
+	var checks []digestCheck
+
+	check := func(name, algorithm, encoded string) {
+		algorithm = strings.ToLower(algorithm)
+		result := digestCheck{header: name, algorithm: algorithm}
+
+		newHash, known := digestAlgorithms[algorithm]
+		expected, err := base64.StdEncoding.DecodeString(encoded)
+		switch {
+		case !known:
+			result.status = "unsupported algorithm"
+		case err != nil:
+			result.status = "malformed value"
+		case decompressed:
+			result.status = "not verifiable (body was decompressed)"
+		default:
+			h := newHash()
+			h.Write(body)
+			result.status = "MISMATCH"
+			if string(h.Sum(nil)) == string(expected) {
+				result.status = "matches"
+			}
+		}
+		checks = append(checks, result)
+	}
+
+	if value := header.Get("Content-MD5"); value != "" {
+		check("Content-MD5", "md5", strings.TrimSpace(value))
+	}
+
+	// Digest (RFC 3230): "SHA-256=<base64>, MD5=<base64>"
+	for _, entry := range splitHeaderList(header.Values("Digest")) {
+		if algorithm, value, found := strings.Cut(entry, "="); found {
+			check("Digest", algorithm, value)
+		}
+	}
+
+	// Content-Digest and Repr-Digest (RFC 9530): "sha-256=:<base64>:"
+	for _, name := range []string{"Content-Digest", "Repr-Digest"} {
+		for _, entry := range splitHeaderList(header.Values(name)) {
+			algorithm, value, found := strings.Cut(entry, "=")
+			if !found {
+				continue
+			}
+			value = strings.TrimSuffix(strings.TrimPrefix(value, ":"), ":")
+			check(name, algorithm, value)
+		}
+	}
+	return checks
+}
+
+// splitHeaderList splits comma-separated header values into trimmed, non-empty entries.
+func splitHeaderList(values []string) []string {
+	var entries []string
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// formatIntegrity renders the body size, hashes and digest checks for the Headers result view.
+func formatIntegrity(resp response) string {
+	var text strings.Builder
+	sha, sum := bodyHashes(resp.Body)
+	text.WriteString(fmt.Sprintf("%s %d bytes\n", styles.HeaderNameStyle.Render("Body size:"), len(resp.Body)))
+	text.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render("SHA-256:"), sha))
+	text.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render("MD5:"), sum))
+
+	for _, c := range verifyDigests(resp.Header, resp.Body, resp.Decompressed) {
+		status := c.status
+		if status == "MISMATCH" {
+			status = styles.DefaultTheme.ErrorStyle.Render(status)
+		}
+		text.WriteString(fmt.Sprintf("%s %s %s\n", styles.HeaderNameStyle.Render("Integrity:"), c.header+" ("+c.algorithm+")", status))
+	}
+	return text.String()
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// TestVerifyDigests checks each digest header against a matching and a tampered body.
+func TestVerifyDigests(t *testing.T) {
+	body := []byte(`{"ok":true}`)
+	sha := sha256.Sum256(body)
+	sum := md5.Sum(body)
+	shaB64 := base64.StdEncoding.EncodeToString(sha[:])
+	md5B64 := base64.StdEncoding.EncodeToString(sum[:])
+
+	header := http.Header{}
+	header.Set("Content-MD5", md5B64)
+	header.Set("Digest", "SHA-256="+shaB64+", UNIXsum=30637")
+	header.Set("Repr-Digest", "sha-256=:"+shaB64+":")
+
+	got := verifyDigests(header, body, false)
+	want := []digestCheck{
+		{"Content-MD5", "md5", "matches"},
+		{"Digest", "sha-256", "matches"},
+		{"Digest", "unixsum", "unsupported algorithm"},
+		{"Repr-Digest", "sha-256", "matches"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("verifyDigests() = %v, want %v", got, want)
+	}
+
+	// A different body no longer matches
+	if got := verifyDigests(header, []byte("tampered"), false); got[0].status != "MISMATCH" || got[3].status != "MISMATCH" {
+		t.Errorf("tampered body: %v, want mismatches", got)
+	}
+
+	// Every digest, Repr-Digest included, covers the content coding, so none can be checked
+	// against a body the transport decompressed
+	for _, c := range verifyDigests(header, body, true) {
+		if c.algorithm != "unixsum" && c.status != "not verifiable (body was decompressed)" {
+			t.Errorf("decompressed body: %s (%s) %s, want not verifiable", c.header, c.algorithm, c.status)
+		}
+	}
+}
+
+// TestBodyHashes checks the hashes against known values for an empty body.
+func TestBodyHashes(t *testing.T) {
+	sha, sum := bodyHashes(nil)
+	if sha != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" || sum != "d41d8cd98f00b204e9800998ecf8427e" {
+		t.Errorf("bodyHashes(nil) = %s, %s", sha, sum)
+	}
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// job is a long-running operation, such as sending a request, run in the background.
+// run reports steps through progress and returns the message to deliver on success.
+// It should stop early, returning ctx.Err(), when ctx is canceled.
+type job struct {
+	name string                                                            // Name used in progress and error messages, e.g. "Request"
+	run  func(ctx context.Context, progress func(string)) (tea.Msg, error) // The work itself
+	sent *sentRequest                                                      // Request the job sends from the form, reported if it fails; nil for other jobs
+}
+
+// jobProgressMsg reports a step of a running job, e.g. "Sending request...".
+type jobProgressMsg struct {
+	id      int            // Job the progress belongs to
+	text    string         // Description of the current step
+	updates <-chan tea.Msg // Channel to keep listening on for the job's next message
+}
+
+// jobDoneMsg reports that a job finished. result is delivered to Update when err is nil.
+type jobDoneMsg struct {
+	id     int          // Job that finished
+	name   string       // Name of the job
+	sent   *sentRequest // Request the job sent from the form, nil for other jobs
+	result tea.Msg      // Message produced by the job on success
+	err    error        // Why the job failed, context.Canceled if it was canceled
+}
+
+// jobRunner starts background jobs and keeps track of the running ones so they can be canceled.
+// The zero value is ready to use.
+type jobRunner struct {
+	nextID  int                        // Id given to the next job
+	cancels map[int]context.CancelFunc // Cancel functions of the running jobs
+	names   map[int]string             // Names of the running jobs
+}
+
+// start runs j in the background and returns the command that delivers its first message.
+// A running job with the same name, e.g. an earlier request, is superseded: it is canceled
+// and forgotten, so that its messages are dropped rather than overwriting the new job's.
+func (r *jobRunner) start(j job) tea.Cmd {
+	if r.cancels == nil {
+		r.cancels = make(map[int]context.CancelFunc)
+		r.names = make(map[int]string)
+	}
+	for id, name := range r.names {
+		if name == j.name {
+			r.finish(id)
+		}
+	}
+	r.nextID++
+	id := r.nextID
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancels[id] = cancel
+	r.names[id] = j.name
+
+	// Progress is dropped rather than blocking the job if the UI falls behind;
+	// the buffer always leaves room for the final message
+	updates := make(chan tea.Msg, 8)
+	progress := func(text string) {
+		select {
+		case updates <- jobProgressMsg{id: id, text: text, updates: updates}:
+		default:
+		}
+	}
+
+	go func() {
+		defer close(updates)
+		result, err := j.run(ctx, progress)
+		if err == nil && ctx.Err() != nil {
+			err = ctx.Err() // Canceled after the work completed; the result is stale
+		}
+		updates <- jobDoneMsg{id: id, name: j.name, sent: j.sent, result: result, err: err}
+	}()
+
+	return waitForJob(updates)
+}
+
+// finish forgets a job that has delivered its jobDoneMsg, canceling it if it still runs, and
+// reports whether it was current: started and neither finished nor superseded since.
+func (r *jobRunner) finish(id int) bool {
+	cancel, ok := r.cancels[id]
+	if ok {
+		cancel()
+		delete(r.cancels, id)
+		delete(r.names, id)
+	}
+	return ok
+}
+
+// current reports whether the job with id is running and has not been superseded.
+func (r *jobRunner) current(id int) bool {
+	_, ok := r.cancels[id]
+	return ok
+}
+
+// cancelAll cancels every running job and reports whether there were any.
+// Each job still delivers a jobDoneMsg, with context.Canceled as its error.
+func (r *jobRunner) cancelAll() bool {
+	for _, cancel := range r.cancels {
+		cancel()
+	}
+	return len(r.cancels) > 0
+}
+
+// running reports whether any job has not finished yet.
+func (r *jobRunner) running() bool {
+	return len(r.cancels) > 0
+}
+
+// waitForJob returns a command that waits for the next message of a job.
+func waitForJob(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+// handleJobProgressMsg shows a job's current step in the spinner and keeps listening for the job.
+func (a *App) handleJobProgressMsg(msg jobProgressMsg) tea.Cmd {
+	if a.spinner.Visible && a.jobs.current(msg.id) {
+		a.spinner.Message = msg.text
+	}
+	return waitForJob(msg.updates)
+}
+
+// handleJobDoneMsg delivers a finished job's result, or reports its failure or cancellation
+// the same way for every job: the spinner is hidden, a toast explains what happened and
+// the URL input is focused so the request can be corrected or sent again. Failed HTTP
+// requests are also explained in the Result view, with guidance and the error chain.
+// Messages of superseded jobs are dropped.
+func (a *App) handleJobDoneMsg(msg jobDoneMsg) tea.Cmd {
+	if !a.jobs.finish(msg.id) {
+		return nil
+	}
+
+	if msg.err == nil {
+		_, cmd := a.Update(msg.result)
+		return cmd
+	}
+
+	a.spinner.Hide()
+	var reqErr *requestError
+	if errors.Is(msg.err, context.Canceled) {
+		a.toast.Show(fmt.Sprintf("%s canceled", msg.name))
+	} else if errors.As(msg.err, &reqErr) {
+		hint := ""
+		if a.recordFailure(msg) {
+			hint = ", Alt+F exports a report"
+		}
+		resultTab := a.tabContainer.GetResultTab()
+		resultTab.SetHeadersContent(formatRequestFailure(msg.name, reqErr))
+		resultTab.SetBodyContent("")
+		a.revealResult(0)
+		a.toast.Show(fmt.Sprintf("%s failed: %s (details in the Result tab%s)", msg.name, reqErr.kind.title(), hint))
+	} else {
+		a.recordFailure(msg)
+		a.toast.Show(fmt.Sprintf("%s failed: %v", msg.name, msg.err))
+	}
+	a.setFocus(focusURL)
+	return nil
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestJobRunnerDeliversProgressAndResult checks that progress arrives before the result.
+func TestJobRunnerDeliversProgressAndResult(t *testing.T) {
+	var runner jobRunner
+	cmd := runner.start(job{name: "Test", run: func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		progress("working")
+		return "result", nil
+	}})
+
+	progress, ok := cmd().(jobProgressMsg)
+	if !ok || progress.text != "working" {
+		t.Fatalf("first message = %#v, want progress", progress)
+	}
+
+	done, ok := waitForJob(progress.updates)().(jobDoneMsg)
+	if !ok || done.err != nil || done.result != "result" {
+		t.Fatalf("second message = %#v, want a successful result", done)
+	}
+	if !runner.running() {
+		t.Error("the job should count as running until it is finished")
+	}
+	runner.finish(done.id)
+	if runner.running() {
+		t.Error("no job should be running after finish")
+	}
+}
+
+// TestJobRunnerCancel checks that canceling a job delivers context.Canceled.
+func TestJobRunnerCancel(t *testing.T) {
+	var runner jobRunner
+	cmd := runner.start(job{name: "Test", run: func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}})
+
+	if !runner.cancelAll() {
+		t.Fatal("cancelAll() = false, want true with a running job")
+	}
+	done, ok := cmd().(jobDoneMsg)
+	if !ok || !errors.Is(done.err, context.Canceled) {
+		t.Fatalf("message = %#v, want a canceled job", done)
+	}
+}
+
+// TestJobRunnerSupersede checks that a new job cancels a running job with the same name,
+// whose messages are then dropped, and leaves jobs with other names running.
+func TestJobRunnerSupersede(t *testing.T) {
+	app := NewApp(config.Config{})
+	wait := func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	first := app.jobs.start(job{name: requestJobName, run: wait})
+	other := app.jobs.start(job{name: "Comparison", run: wait})
+	app.jobs.start(job{name: requestJobName, run: wait})
+	app.spinner.Show("Sending request...")
+
+	done, ok := first().(jobDoneMsg)
+	if !ok || !errors.Is(done.err, context.Canceled) {
+		t.Fatalf("message = %#v, want the superseded job canceled", done)
+	}
+	if cmd := app.handleJobDoneMsg(done); cmd != nil || !app.spinner.Visible || app.toast.Visible {
+		t.Error("the superseded job's cancellation should be dropped without hiding the spinner or showing a toast")
+	}
+	if !app.jobs.running() {
+		t.Error("the new job should still be running")
+	}
+
+	app.jobs.cancelAll()
+	done = other().(jobDoneMsg)
+	if !app.jobs.finish(done.id) {
+		t.Error("a job with another name should not be superseded")
+	}
+}
@@ -13,6 +13,32 @@ type KeyMap struct {
 	Next        key.Binding // Tab: Navigate to next inner tab
 	Prev        key.Binding // Shift+Tab: Navigate to previous inner tab
 	Quit        key.Binding // Ctrl+C/Esc: Quit the application
+
+	ExportRequest key.Binding // Alt+E: Export the request to a file and the clipboard
+	ImportRequest key.Binding // Alt+I: Import a request from the clipboard
+
+	DiscoverServices key.Binding // Alt+D: Pick a local service as the request target
+	Compare          key.Binding // Alt+C: Compare the response with another base URL
+	InspectToken     key.Binding // Alt+T: Decode a JWT or opaque token
+	LatencyBudget    key.Binding // Alt+L: Set the latency budget of the request
+	AutoResend       key.Binding // Alt+R: Toggle resending when a Retry-After window elapses
+	PreviewRequest   key.Binding // Alt+P: Show the raw request with the source of each header
+	RecentRequests   key.Binding // Alt+O: Switch between recently used requests
+	EditEnvironments key.Binding // Alt+V: Edit environments and their variables
+	BypassProxy      key.Binding // Alt+B: Toggle sending the request without the proxy
+	SelectLocale     key.Binding // Alt+A: Set Accept-Language from a list of locales
+	DataBinary       key.Binding // Alt+X: Toggle sending the body byte-for-byte as entered
+	Scratchpad       key.Binding // Alt+U: Encode and decode text (base64, URL, hex, JWT)
+	TimeTool         key.Binding // Alt+N: Insert a timestamp, e.g. now or now+1h, into the focused field
+	PinEnvironment   key.Binding // Alt+M: Pin the request to an environment
+	RawEncoding      key.Binding // Alt+G: Toggle requesting identity encoding without automatic decompression
+	ShowSecrets      key.Binding // Alt+S: Show or hide passwords and tokens in the Auth panel
+	PrivacyMode      key.Binding // Alt+H: Toggle masking secrets everywhere in the UI
+	ErrorReport      key.Binding // Alt+F: Export the context of the last failed request to a file
+	WatchFiles       key.Binding // Alt+W: Toggle resending when the body file or environments change
+	StepUp           key.Binding // Ctrl+Up/Alt+=: Increment the number or date at the cursor
+	StepDown         key.Binding // Ctrl+Down/Alt+-: Decrement the number or date at the cursor
+	RecordSession    key.Binding // Alt+K: Record the requests sent, or stop and export them as a replay script
 }
 
 // DefaultKeyMap returns the default keybindings for the application.
@@ -50,4 +76,100 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("ctrl+c", "esc"),
 		key.WithHelp("ctrl+c/esc", "quit"),
 	),
+	ExportRequest: key.NewBinding(
+		key.WithKeys("alt+e"),
+		key.WithHelp("alt+e", "export request"),
+	),
+	ImportRequest: key.NewBinding(
+		key.WithKeys("alt+i"),
+		key.WithHelp("alt+i", "import request from clipboard"),
+	),
+	DiscoverServices: key.NewBinding(
+		key.WithKeys("alt+d"),
+		key.WithHelp("alt+d", "discover local services"),
+	),
+	Compare: key.NewBinding(
+		key.WithKeys("alt+c"),
+		key.WithHelp("alt+c", "compare with another base url"),
+	),
+	InspectToken: key.NewBinding(
+		key.WithKeys("alt+t"),
+		key.WithHelp("alt+t", "inspect token"),
+	),
+	LatencyBudget: key.NewBinding(
+		key.WithKeys("alt+l"),
+		key.WithHelp("alt+l", "set latency budget"),
+	),
+	AutoResend: key.NewBinding(
+		key.WithKeys("alt+r"),
+		key.WithHelp("alt+r", "toggle auto-resend after retry-after"),
+	),
+	PreviewRequest: key.NewBinding(
+		key.WithKeys("alt+p"),
+		key.WithHelp("alt+p", "preview raw request"),
+	),
+	RecentRequests: key.NewBinding(
+		key.WithKeys("alt+o"),
+		key.WithHelp("alt+o", "switch recent requests"),
+	),
+	EditEnvironments: key.NewBinding(
+		key.WithKeys("alt+v"),
+		key.WithHelp("alt+v", "edit environments"),
+	),
+	BypassProxy: key.NewBinding(
+		key.WithKeys("alt+b"),
+		key.WithHelp("alt+b", "toggle proxy bypass"),
+	),
+	SelectLocale: key.NewBinding(
+		key.WithKeys("alt+a"),
+		key.WithHelp("alt+a", "select locale"),
+	),
+	DataBinary: key.NewBinding(
+		key.WithKeys("alt+x"),
+		key.WithHelp("alt+x", "toggle exact body"),
+	),
+	Scratchpad: key.NewBinding(
+		key.WithKeys("alt+u"),
+		key.WithHelp("alt+u", "encode/decode scratchpad"),
+	),
+	TimeTool: key.NewBinding(
+		key.WithKeys("alt+n"),
+		key.WithHelp("alt+n", "insert timestamp"),
+	),
+	PinEnvironment: key.NewBinding(
+		key.WithKeys("alt+m"),
+		key.WithHelp("alt+m", "pin environment"),
+	),
+	RawEncoding: key.NewBinding(
+		key.WithKeys("alt+g"),
+		key.WithHelp("alt+g", "toggle raw encoding"),
+	),
+	ShowSecrets: key.NewBinding(
+		key.WithKeys("alt+s"),
+		key.WithHelp("alt+s", "show/hide secrets"),
+	),
+	PrivacyMode: key.NewBinding(
+		key.WithKeys("alt+h"),
+		key.WithHelp("alt+h", "toggle privacy mode"),
+	),
+	ErrorReport: key.NewBinding(
+		key.WithKeys("alt+f"),
+		key.WithHelp("alt+f", "export error report"),
+	),
+	WatchFiles: key.NewBinding(
+		key.WithKeys("alt+w"),
+		key.WithHelp("alt+w", "toggle watching files"),
+	),
+	StepUp: key.NewBinding(
+		key.WithKeys("ctrl+up", "alt+="),
+		key.WithHelp("ctrl+↑", "increment number or date"),
+	),
+	StepDown: key.NewBinding(
+		key.WithKeys("ctrl+down", "alt+-"),
+		key.WithHelp("ctrl+↓", "decrement number or date"),
+	),
+	RecordSession: key.NewBinding(
+		key.WithKeys("alt+k"),
+		key.WithHelp("alt+k", "record session"),
+	),
 }
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// latencyBudget returns the latency budget that applies to a request to rawURL.
+// A budget set on the request wins over the last matching host rule with a budget,
+// which wins over the global budget. Zero means no budget.
+func latencyBudget(cfg config.Config, rawURL string, requestBudget time.Duration) time.Duration {
+	if requestBudget > 0 {
+		return requestBudget
+	}
+
+	budget := time.Duration(cfg.LatencyBudgetMS) * time.Millisecond
+	if parsedURL, err := url.Parse(rawURL); err == nil {
+		for _, rule := range cfg.HostRules {
+			if rule.LatencyBudgetMS > 0 && rule.Matches(parsedURL.Hostname()) {
+				budget = time.Duration(rule.LatencyBudgetMS) * time.Millisecond
+			}
+		}
+	}
+	return budget
+}
+
+// formatLatency renders the response time for the Headers result view,
+// flagging it when it exceeds budget.
+func formatLatency(elapsed, budget time.Duration) string {
+	text := elapsed.Round(time.Millisecond).String()
+	if budget <= 0 {
+		return text
+	}
+	if elapsed > budget {
+		return styles.DefaultTheme.ErrorStyle.Render(fmt.Sprintf("%s (over the %s budget by %s)",
+			text, budget, (elapsed - budget).Round(time.Millisecond)))
+	}
+	return fmt.Sprintf("%s (within the %s budget)", text, budget)
+}
+
+// parseLatencyBudget parses a budget entered in milliseconds. An empty value clears the budget.
+func parseLatencyBudget(value string) (time.Duration, error) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "ms")
+	if value == "" {
+		return 0, nil
+	}
+	ms, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || ms < 0 {
+		return 0, fmt.Errorf("latency budget must be a number of milliseconds, got %q", value)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// handleLatencyBudget asks for the latency budget of the current request.
+func (a *App) handleLatencyBudget() tea.Cmd {
+	value := ""
+	if a.latencyBudget > 0 {
+		value = strconv.FormatInt(a.latencyBudget.Milliseconds(), 10)
+	}
+	a.promptMode = promptLatencyBudget
+	return a.prompt.Open("Latency budget in ms (empty to use the configured budget)", "e.g. 250", value)
+}
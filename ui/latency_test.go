@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestLatencyBudget checks that a request budget wins over the last matching host rule,
+// which wins over the global budget.
+func TestLatencyBudget(t *testing.T) {
+	cfg := config.Config{
+		LatencyBudgetMS: 1000,
+		HostRules: []config.HostRule{
+			{Host: "*.example.com", LatencyBudgetMS: 500},
+			{Host: "api.example.com", LatencyBudgetMS: 200},
+			{Host: "api.example.com", Headers: map[string]string{"X-Team": "core"}}, // No budget, does not reset it
+		},
+	}
+
+	tests := []struct {
+		name    string
+		cfg     config.Config
+		url     string
+		request time.Duration
+		want    time.Duration
+	}{
+		{"request budget wins", cfg, "https://api.example.com/items", 50 * time.Millisecond, 50 * time.Millisecond},
+		{"last matching host rule", cfg, "https://api.example.com/items", 0, 200 * time.Millisecond},
+		{"earlier matching host rule", cfg, "https://www.example.com/", 0, 500 * time.Millisecond},
+		{"global budget", cfg, "https://other.test/", 0, time.Second},
+		{"unparsable URL uses the global budget", cfg, "://api.example.com", 0, time.Second},
+		{"no budget", config.Config{}, "https://api.example.com/", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := latencyBudget(tt.cfg, tt.url, tt.request); got != tt.want {
+				t.Errorf("latencyBudget() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseLatencyBudget checks the accepted forms of a budget and that negative or
+// non-numeric values are rejected.
+func TestParseLatencyBudget(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"250", 250 * time.Millisecond, false},
+		{"250ms", 250 * time.Millisecond, false},
+		{" 250 ms ", 250 * time.Millisecond, false},
+		{"", 0, false},
+		{"0", 0, false},
+		{"-5", 0, true},
+		{"fast", 0, true},
+		{"1.5", 0, true},
+		{"2s", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseLatencyBudget(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseLatencyBudget(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseLatencyBudget(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormatLatency checks how the response time is described against its budget.
+func TestFormatLatency(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		budget  time.Duration
+		want    string
+	}{
+		{"no budget", 123456 * time.Microsecond, 0, "123ms"},
+		{"within budget", 120 * time.Millisecond, 250 * time.Millisecond, "120ms (within the 250ms budget)"},
+		{"exactly on budget", 250 * time.Millisecond, 250 * time.Millisecond, "250ms (within the 250ms budget)"},
+		{"over budget", 400 * time.Millisecond, 250 * time.Millisecond, "400ms (over the 250ms budget by 150ms)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Over budget is styled as an error; only the text is compared
+			if got := ansi.Strip(formatLatency(tt.elapsed, tt.budget)); got != tt.want {
+				t.Errorf("formatLatency() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
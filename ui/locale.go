@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/ui/components"
+)
+
+// locales are offered by the locale selector, as BCP 47 language tags.
+var locales = []struct {
+	tag  string // Language tag, e.g. "de-DE"
+	name string // English name shown in the picker
+}{
+	{"en-US", "English (United States)"},
+	{"en-GB", "English (United Kingdom)"},
+	{"de-DE", "German (Germany)"},
+	{"fr-FR", "French (France)"},
+	{"fr-CA", "French (Canada)"},
+	{"es-ES", "Spanish (Spain)"},
+	{"es-MX", "Spanish (Mexico)"},
+	{"it-IT", "Italian (Italy)"},
+	{"pt-BR", "Portuguese (Brazil)"},
+	{"nl-NL", "Dutch (Netherlands)"},
+	{"sv-SE", "Swedish (Sweden)"},
+	{"pl-PL", "Polish (Poland)"},
+	{"tr-TR", "Turkish (Turkey)"},
+	{"ru-RU", "Russian (Russia)"},
+	{"ar-SA", "Arabic (Saudi Arabia)"},
+	{"he-IL", "Hebrew (Israel)"},
+	{"hi-IN", "Hindi (India)"},
+	{"ja-JP", "Japanese (Japan)"},
+	{"ko-KR", "Korean (South Korea)"},
+	{"zh-CN", "Chinese (Simplified, China)"},
+	{"zh-TW", "Chinese (Traditional, Taiwan)"},
+}
+
+// localeHeaders are the headers set by the locale selector.
+var localeHeaders = []string{"Accept-Language", "Content-Language"}
+
+// acceptLanguage builds an Accept-Language value preferring tag, then its base language,
+// then English as a fallback, e.g. "de-DE,de;q=0.9,en;q=0.8".
+func acceptLanguage(tag string) string {
+	values := []string{tag}
+	base, _, hasRegion := strings.Cut(tag, "-")
+	if hasRegion {
+		values = append(values, base+";q=0.9")
+	}
+	if base != "en" {
+		values = append(values, "en;q=0.8")
+	}
+	return strings.Join(values, ",")
+}
+
+// localeHeaderValues returns the headers that ask for responses in tag. Content-Language
+// is only set when the request has a body, since it describes the body's language.
+// An empty tag returns no headers.
+func localeHeaderValues(tag string, hasBody bool) map[string]string {
+	headers := map[string]string{}
+	if tag == "" {
+		return headers
+	}
+	headers["Accept-Language"] = acceptLanguage(tag)
+	if hasBody {
+		headers["Content-Language"] = tag
+	}
+	return headers
+}
+
+// handleLocaleSelector opens the picker with the locales to request responses in.
+func (a *App) handleLocaleSelector() {
+	a.picker.Open("Locale", "")
+	a.pickerMode = pickerLocale
+
+	items := make([]components.PickerItem, 0, len(locales)+1)
+	for _, locale := range locales {
+		items = append(items, components.PickerItem{
+			Label: fmt.Sprintf("%-8s %s", locale.tag, locale.name),
+			Value: locale.tag,
+		})
+	}
+	items = append(items, components.PickerItem{Label: "Remove locale headers", Value: ""})
+	a.picker.SetItems(items, "")
+}
+
+// applyLocale replaces the locale headers of the request with those for tag,
+// or removes them if tag is empty. Other headers are kept.
+func (a *App) applyLocale(tag string) {
+	queryTab := a.tabContainer.GetQueryTab()
+
+	headers := queryTab.HeadersInput.GetHeaders()
+	for name := range headers {
+		for _, localeHeader := range localeHeaders {
+			if strings.EqualFold(name, localeHeader) {
+				delete(headers, name)
+			}
+		}
+	}
+	for name, value := range localeHeaderValues(tag, strings.TrimSpace(queryTab.GetBodyContent()) != "") {
+		headers[name] = value
+	}
+
+	if filled := queryTab.HeadersInput.SetHeaders(headers); filled < len(headers) {
+		a.toast.Show(fmt.Sprintf("%d headers dropped (no free rows)", len(headers)-filled))
+	} else if tag == "" {
+		a.toast.Show("Locale headers removed")
+	} else {
+		a.toast.Show(fmt.Sprintf("Accept-Language: %s", headers["Accept-Language"]))
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+)
+
+// TestAcceptLanguage checks the fallbacks added after the chosen locale.
+func TestAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"de-DE", "de-DE,de;q=0.9,en;q=0.8"},
+		{"en-GB", "en-GB,en;q=0.9"},
+		{"fr", "fr,en;q=0.8"},
+	}
+
+	for _, tt := range tests {
+		if got := acceptLanguage(tt.tag); got != tt.want {
+			t.Errorf("acceptLanguage(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+// TestApplyLocale checks that the locale headers are replaced or removed and other headers kept.
+func TestApplyLocale(t *testing.T) {
+	app := NewApp(config.Config{})
+	headersInput := &app.tabContainer.GetQueryTab().HeadersInput
+	headersInput.SetHeaders(map[string]string{"Accept": "application/json", "accept-language": "fr"})
+
+	app.applyLocale("ja-JP")
+	headers := headersInput.GetHeaders()
+	if headers["Accept-Language"] != "ja-JP,ja;q=0.9,en;q=0.8" || headers["Accept"] != "application/json" {
+		t.Errorf("headers = %v, want Accept kept and Accept-Language for ja-JP", headers)
+	}
+	if _, ok := headers["accept-language"]; ok {
+		t.Error("the previous Accept-Language header was not replaced")
+	}
+	if _, ok := headers["Content-Language"]; ok {
+		t.Error("Content-Language should only be set for requests with a body")
+	}
+
+	app.applyLocale("")
+	if headers := headersInput.GetHeaders(); len(headers) != 1 {
+		t.Errorf("headers = %v, want only Accept after removing the locale", headers)
+	}
+}
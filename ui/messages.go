@@ -1,9 +1,29 @@
 package ui
 
+import (
+	"time"
+
+	"github.com/RAshkettle/LazyPost/discovery"
+)
+
 // RequestCompleteMsg is sent when an HTTP request has completed.
-// It contains the response data from the request.
+// It contains the response data from the request; failures are reported by jobDoneMsg.
 type RequestCompleteMsg struct {
-	Headers string // Formatted headers string
-	Body    string // Response body text
-	Error   error  // Any error that occurred during the request
+	Headers     string        // Formatted headers string
+	Body        []byte        // Raw response body bytes
+	ContentType string        // Content-Type header of the response
+	RetryAfter  time.Duration // Wait requested by a 429 or 503 response's Retry-After header
+	HasRetry    bool          // Whether RetryAfter is set
+	StatusCode  int           // Status code of the response
+}
+
+// ServicesDiscoveredMsg is sent when the scan for local services has finished.
+type ServicesDiscoveredMsg struct {
+	Services []discovery.Service // Services found on localhost, sorted by port
+}
+
+// CompareCompleteMsg is sent when both requests of a response comparison have completed.
+type CompareCompleteMsg struct {
+	Summary string // Formatted URLs and statuses of both responses
+	Diff    string // Unified diff of the two responses, empty when they are identical
 }
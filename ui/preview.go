@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/redact"
+	"github.com/RAshkettle/LazyPost/ui/styles"
+)
+
+// handlePreviewRequest shows the request that would be sent, with every header
+// and query parameter annotated with where it came from.
+func (a *App) handlePreviewRequest() {
+	rawURL := a.requestURL()
+	if !validateURL(rawURL) {
+		a.toast.Show("Invalid URL: The Provided URL is not valid.")
+		return
+	}
+
+	prepared, err := a.prepareRequest(rawURL)
+	if err != nil {
+		a.toast.Show(fmt.Sprintf("Error building URL: %v", err))
+		return
+	}
+
+	preview, err := renderRequestPreview(prepared)
+	if err != nil {
+		a.toast.Show(fmt.Sprintf("Error building preview: %v", err))
+		return
+	}
+	// Secret variables are expanded into the URL, parameters and body, not only sensitive headers
+	if a.privacyMode {
+		preview = maskSecretValues(preview, prepared.secrets, redact.Placeholder)
+	}
+	a.textViewer.Open("Request preview", a.privacyFilter(preview))
+}
+
+// renderRequestPreview renders prepared as a raw HTTP/1.1 request followed by its
+// query parameters, annotating each header and parameter with its source.
+// Vault placeholders are shown unresolved.
+func renderRequestPreview(prepared preparedRequest) (string, error) {
+	finalURL, err := url.Parse(prepared.finalURL)
+	if err != nil {
+		return "", err
+	}
+	entered, err := url.Parse(prepared.rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	type annotatedLine struct {
+		text   string
+		source string
+	}
+	var headerLines []annotatedLine
+
+	// A Host header naming another host is sent to the URL's host, like curl --connect-to
+	headers, override, err := splitHostOverride(prepared.finalURL, prepared.headers)
+	if err != nil {
+		return "", err
+	}
+	if override == nil {
+		headerLines = append(headerLines, annotatedLine{"Host: " + finalURL.Host, "URL"})
+	} else {
+		source := fmt.Sprintf("%s, connecting to %s", prepared.sources["Host"], override.target)
+		if finalURL.Scheme == "https" {
+			source += ", TLS server name " + override.serverName()
+		}
+		if prepared.route.proxy != nil {
+			source = prepared.sources["Host"] + ", resolved by the proxy"
+		}
+		headerLines = append(headerLines, annotatedLine{"Host: " + override.name, source})
+	}
+	headers, trailers := splitTrailers(headers)
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		headerLines = append(headerLines, annotatedLine{name + ": " + headers[name], prepared.sources[name]})
+	}
+
+	// Fields named by a Trailer header follow the body instead
+	var trailerLines []annotatedLine
+	if trailers != nil {
+		trailerNames := make([]string, 0, len(trailers))
+		for name := range trailers {
+			trailerNames = append(trailerNames, name)
+		}
+		sort.Strings(trailerNames)
+		headerLines = append(headerLines,
+			annotatedLine{"Trailer: " + strings.Join(trailerNames, ", "), prepared.sources["Trailer"]},
+			annotatedLine{"Transfer-Encoding: chunked", "added for the trailers"})
+		for _, name := range trailerNames {
+			if len(trailers[name]) == 0 {
+				trailerLines = append(trailerLines, annotatedLine{name + ":", "declared without a value, not sent"})
+				continue
+			}
+			trailerLines = append(trailerLines, annotatedLine{name + ": " + trailers[name][0], prepared.sources[name]})
+		}
+	}
+	// Go asks for gzip itself unless the request sets Accept-Encoding or Range, or is a HEAD
+	_, hasEncoding := prepared.headers["Accept-Encoding"]
+	_, hasRange := prepared.headers["Range"]
+	if !hasEncoding && !hasRange && prepared.method != "HEAD" {
+		headerLines = append(headerLines, annotatedLine{"Accept-Encoding: gzip", "added by Go, decompressed automatically (Alt+G for raw)"})
+	}
+
+	// Parameters typed into the URL keep their place; the Params tab adds to them
+	var paramLines []annotatedLine
+	enteredQuery := entered.Query()
+	for _, name := range sortedKeys(enteredQuery) {
+		for _, value := range enteredQuery[name] {
+			paramLines = append(paramLines, annotatedLine{name + " = " + value, "URL"})
+		}
+	}
+	paramNames := make([]string, 0, len(prepared.params))
+	for name := range prepared.params {
+		if strings.TrimSpace(name) != "" {
+			paramNames = append(paramNames, name)
+		}
+	}
+	sort.Strings(paramNames)
+	for _, name := range paramNames {
+		paramLines = append(paramLines, annotatedLine{name + " = " + prepared.params[name], "Params tab"})
+	}
+
+	// Align the annotations in one column
+	width := 0
+	for _, line := range slices.Concat(headerLines, trailerLines, paramLines) {
+		width = max(width, len(line.text))
+	}
+	render := func(line annotatedLine) string {
+		return fmt.Sprintf("%-*s  %s", width, line.text, styles.DefaultTheme.HelpTextStyle.Render("# "+line.source))
+	}
+
+	var preview strings.Builder
+	preview.WriteString(styles.DefaultTheme.HelpTextStyle.Render("# Route: "+prepared.route.String()) + "\n")
+	preview.WriteString(fmt.Sprintf("%s %s HTTP/1.1\n", prepared.method, finalURL.RequestURI()))
+	for _, line := range headerLines {
+		preview.WriteString(render(line) + "\n")
+	}
+
+	if len(paramLines) > 0 {
+		preview.WriteString("\n" + styles.HeaderNameStyle.Render("Query parameters:") + "\n")
+		for _, line := range paramLines {
+			preview.WriteString(render(line) + "\n")
+		}
+	}
+
+	if prepared.body != "" {
+		mode := "trailing line breaks trimmed"
+		if prepared.dataBinary {
+			mode = "sent byte-for-byte as entered"
+		}
+		preview.WriteString("\n" + styles.HeaderNameStyle.Render(fmt.Sprintf("Body (%d bytes, %s):", len(prepared.body), mode)) + "\n")
+		preview.WriteString(prepared.body + "\n")
+	}
+
+	if len(trailerLines) > 0 {
+		preview.WriteString("\n" + styles.HeaderNameStyle.Render("Trailers (sent after the body):") + "\n")
+		for _, line := range trailerLines {
+			preview.WriteString(render(line) + "\n")
+		}
+	}
+
+	// Placeholders that resolve to nothing are sent literally
+	if len(prepared.lint.unresolved) > 0 || len(prepared.lint.unused) > 0 {
+		preview.WriteString("\n" + styles.HeaderNameStyle.Render("Variables:") + "\n")
+		if len(prepared.lint.unresolved) > 0 {
+			names := make([]string, len(prepared.lint.unresolved))
+			for i, name := range prepared.lint.unresolved {
+				names[i] = "{{" + name + "}}"
+			}
+			preview.WriteString("Unresolved: " + strings.Join(names, ", ") + "\n")
+		}
+		if len(prepared.lint.unused) > 0 {
+			preview.WriteString("Unused: " + strings.Join(prepared.lint.unused, ", ") + "\n")
+		}
+	}
+	return preview.String(), nil
+}
+
+// sortedKeys returns the keys of values in sorted order.
+func sortedKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
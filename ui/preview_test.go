@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestRenderRequestPreview tests that merged headers and parameters are annotated with their sources.
+func TestRenderRequestPreview(t *testing.T) {
+	rules := []config.HostRule{{Host: "api.example.com", Headers: map[string]string{"Accept": "text/plain", "X-Team": "core"}}}
+	sources := make(map[string]string)
+
+	rawURL := "https://api.example.com/items?page=2"
+	params := map[string]string{"limit": "10"}
+	finalURL, err := buildURLWithParams(rawURL, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers, _ := hostRuleHeaders(rules, finalURL, sources)
+	mergeHeaders(headers, map[string]string{"accept": "application/json"}, sources, "Headers tab")
+
+	preview, err := renderRequestPreview(preparedRequest{
+		method:   "GET",
+		rawURL:   rawURL,
+		finalURL: finalURL,
+		params:   params,
+		headers:  headers,
+		sources:  sources,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	preview = ansi.Strip(preview)
+
+	for _, expected := range []string{
+		"GET /items?limit=10&page=2 HTTP/1.1",
+		"# URL",
+		"# Headers tab (overrides host rule api.example.com)",
+		"X-Team: core",
+		"page = 2",
+		"limit = 10",
+		"# Params tab",
+	} {
+		if !strings.Contains(preview, expected) {
+			t.Errorf("preview does not contain %q:\n%s", expected, preview)
+		}
+	}
+}
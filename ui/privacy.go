@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/env"
+)
+
+// handleToggleSecrets shows or hides the Basic password, Bearer token and OAuth2 access
+// token in the Auth panel. In privacy mode secrets stay hidden.
+func (a *App) handleToggleSecrets() {
+	if a.privacyMode {
+		a.toast.Show("Secrets stay hidden in privacy mode: turn it off with Alt+H first")
+		return
+	}
+	a.secretsShown = !a.secretsShown
+	a.applySecretMasking()
+	if a.secretsShown {
+		a.toast.Show("Passwords and tokens are shown in plain text")
+	} else {
+		a.toast.Show("Passwords and tokens are hidden")
+	}
+}
+
+// handleTogglePrivacy switches privacy mode, which masks secrets everywhere in the UI:
+// auth inputs, sensitive header values, the response views and the request preview.
+func (a *App) handleTogglePrivacy() {
+	a.privacyMode = !a.privacyMode
+	a.applySecretMasking()
+	if a.privacyMode {
+		a.toast.Show("Privacy mode on: secrets are masked everywhere")
+	} else {
+		a.toast.Show("Privacy mode off")
+	}
+}
+
+// applySecretMasking updates the components to the current secret visibility and privacy mode.
+func (a *App) applySecretMasking() {
+	queryTab := a.tabContainer.GetQueryTab()
+	queryTab.AuthInput.SetSecretsMasked(a.privacyMode || !a.secretsShown)
+
+	resultTab := a.tabContainer.GetResultTab()
+	if a.privacyMode {
+		queryTab.HeadersInput.SetMaskedHeaders(a.privacyRedactor.IsSensitiveHeader)
+		resultTab.SetDisplayFilter(a.privacyRedactor.Text)
+	} else {
+		queryTab.HeadersInput.SetMaskedHeaders(nil)
+		resultTab.SetDisplayFilter(nil)
+	}
+}
+
+// privacyFilter masks secrets in text shown in privacy mode and returns other text unchanged.
+func (a *App) privacyFilter(text string) string {
+	if !a.privacyMode {
+		return text
+	}
+	return a.privacyRedactor.Text(text)
+}
+
+// privacyPrefill returns text to prefill a tool that shows it in full, such as the token
+// inspector or the scratchpad, or nothing in privacy mode, where the tool opens empty.
+func (a *App) privacyPrefill(text string) string {
+	if a.privacyMode {
+		return ""
+	}
+	return text
+}
+
+// secretValues returns the values of the secret variables of e that templates reference, by
+// variable name, so that they can be masked in text built from the expanded request. e may
+// be nil, in which case there are none.
+func secretValues(e *env.Environment, templates []string) map[string]string {
+	secrets := make(map[string]string)
+	for _, template := range templates {
+		for _, name := range env.Placeholders(template) {
+			if v, ok := e.Lookup(name); ok && v.Secret && v.Value != "" {
+				secrets[name] = v.Value
+			}
+		}
+	}
+	return secrets
+}
+
+// maskSecretValues replaces every occurrence of the values of secrets in text, also in
+// their URL-encoded forms, with mask. Longer values are replaced first, so that a secret
+// containing another one is masked whole.
+func maskSecretValues(text string, secrets map[string]string, mask string) string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, value := range secrets {
+		for _, form := range []string{value, url.QueryEscape(value), url.PathEscape(value)} {
+			if !seen[form] {
+				seen[form] = true
+				values = append(values, form)
+			}
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, mask)
+	}
+	return text
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/config"
+)
+
+// proxyRoute is how a request reaches its host: through a proxy or directly.
+type proxyRoute struct {
+	proxy  *url.URL // Proxy to send through, nil for a direct connection
+	reason string   // Why this route was chosen, e.g. "NO_PROXY entry .internal"
+}
+
+// String describes the route for the Headers result view and the request preview.
+func (r proxyRoute) String() string {
+	if r.proxy == nil {
+		return "direct (" + r.reason + ")"
+	}
+	return fmt.Sprintf("via proxy %s (%s)", r.proxy.Redacted(), r.reason)
+}
+
+// transport returns a new HTTP transport that follows the route. The caller closes its idle
+// connections when done with it.
+func (r proxyRoute) transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(r.proxy)
+	return transport
+}
+
+// routeFor decides how a request to requestURL is routed, reading proxy settings from the environment.
+func (a *App) routeFor(requestURL string) (proxyRoute, error) {
+	return resolveRoute(a.config, requestURL, a.bypassProxy, os.Getenv)
+}
+
+// resolveRoute decides how a request to requestURL is routed. In order of precedence:
+// a per-request bypass, a host rule bypass, no proxy being configured, loopback hosts
+// (never proxied, as in Go's standard library), NO_PROXY and the no_proxy config list.
+// The proxy is the configured one, or HTTPS_PROXY/HTTP_PROXY from the environment.
+func resolveRoute(cfg config.Config, requestURL string, bypass bool, getenv func(string) string) (proxyRoute, error) {
+	target, err := url.Parse(requestURL)
+	if err != nil {
+		return proxyRoute{}, err
+	}
+	host := target.Hostname()
+
+	if bypass {
+		return proxyRoute{reason: "proxy bypassed for this request"}, nil
+	}
+	for _, rule := range cfg.HostRules {
+		if rule.BypassProxy && rule.Matches(host) {
+			return proxyRoute{reason: "proxy bypassed by host rule " + rule.Host}, nil
+		}
+	}
+
+	proxyValue, source := cfg.Proxy, "config"
+	if proxyValue == "" {
+		proxyValue, source = environmentProxy(target.Scheme, getenv)
+	}
+	if proxyValue == "" {
+		return proxyRoute{reason: "no proxy configured"}, nil
+	}
+
+	if isLoopback(host) {
+		return proxyRoute{reason: "loopback addresses are never proxied"}, nil
+	}
+
+	port := target.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[target.Scheme]
+	}
+	for _, variable := range []string{"NO_PROXY", "no_proxy"} {
+		if entry, ok := matchNoProxy(splitNoProxy(getenv(variable)), host, port); ok {
+			return proxyRoute{reason: variable + " entry " + entry}, nil
+		}
+	}
+	if entry, ok := matchNoProxy(cfg.NoProxy, host, port); ok {
+		return proxyRoute{reason: "no_proxy config entry " + entry}, nil
+	}
+
+	proxyURL, err := parseProxyURL(proxyValue)
+	if err != nil {
+		return proxyRoute{}, fmt.Errorf("invalid proxy %q from %s: %w", proxyValue, source, err)
+	}
+	return proxyRoute{proxy: proxyURL, reason: source}, nil
+}
+
+// environmentProxy returns the proxy set in the environment for scheme and the variable it came from.
+func environmentProxy(scheme string, getenv func(string) string) (string, string) {
+	variables := []string{"HTTP_PROXY", "http_proxy"}
+	if scheme == "https" {
+		variables = []string{"HTTPS_PROXY", "https_proxy"}
+	}
+	for _, variable := range variables {
+		if value := getenv(variable); value != "" {
+			return value, variable
+		}
+	}
+	return "", ""
+}
+
+// parseProxyURL parses a proxy address, assuming http:// when no scheme is given.
+func parseProxyURL(value string) (*url.URL, error) {
+	proxyURL, err := url.Parse(value)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		proxyURL, err = url.Parse("http://" + value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("missing host")
+	}
+	return proxyURL, nil
+}
+
+// splitNoProxy splits a comma-separated NO_PROXY value into trimmed, non-empty entries.
+func splitNoProxy(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// matchNoProxy returns the first entry that excludes host and port from proxying.
+// Entries are "*", IP addresses, CIDR ranges or domain names (which also cover their
+// subdomains, with or without a leading dot), optionally followed by ":port".
+func matchNoProxy(entries []string, host, port string) (string, bool) {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+
+	for _, entry := range entries {
+		if entry == "*" {
+			return entry, true
+		}
+
+		pattern := strings.ToLower(entry)
+		if _, network, err := net.ParseCIDR(pattern); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return entry, true
+			}
+			continue
+		}
+
+		if h, p, err := net.SplitHostPort(pattern); err == nil {
+			if p != port {
+				continue
+			}
+			pattern = h
+		}
+
+		if patternIP := net.ParseIP(strings.Trim(pattern, "[]")); patternIP != nil {
+			if ip != nil && patternIP.Equal(ip) {
+				return entry, true
+			}
+			continue
+		}
+
+		domain := strings.TrimPrefix(strings.TrimPrefix(pattern, "*"), ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// isLoopback reports whether host is localhost or a loopback IP address.
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// handleToggleProxyBypass toggles sending the current request without a proxy.
+func (a *App) handleToggleProxyBypass() {
+	a.bypassProxy = !a.bypassProxy
+	state := "off"
+	if a.bypassProxy {
+		state = "on"
+	}
+	a.toast.Show(fmt.Sprintf("Proxy bypass for this request is %s", state))
+}
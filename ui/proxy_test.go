@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/config"
+)
+
+// TestResolveRoute checks the precedence of per-request and host rule bypasses,
+// loopback hosts, NO_PROXY and the configured proxy.
+func TestResolveRoute(t *testing.T) {
+	env := map[string]string{
+		"HTTPS_PROXY": "proxy.corp:3128",
+		"NO_PROXY":    ".internal, 10.0.0.0/8, api.example.com:8443",
+	}
+	getenv := func(name string) string { return env[name] }
+
+	cfg := config.Config{
+		HostRules: []config.HostRule{{Host: "*.direct.example.com", BypassProxy: true}},
+		NoProxy:   []string{"partner.example.org"},
+	}
+
+	tests := []struct {
+		name       string
+		cfg        config.Config
+		url        string
+		bypass     bool
+		wantProxy  string
+		wantReason string
+	}{
+		{"Proxied from the environment", cfg, "https://api.example.com/x", false, "http://proxy.corp:3128", "HTTPS_PROXY"},
+		{"Per-request bypass", cfg, "https://api.example.com/x", true, "", "for this request"},
+		{"Host rule bypass", cfg, "https://a.direct.example.com", false, "", "host rule *.direct.example.com"},
+		{"Loopback", cfg, "https://localhost:8080", false, "", "loopback"},
+		{"NO_PROXY domain suffix", cfg, "https://db.internal/x", false, "", "NO_PROXY entry .internal"},
+		{"NO_PROXY CIDR", cfg, "https://10.1.2.3/x", false, "", "NO_PROXY entry 10.0.0.0/8"},
+		{"NO_PROXY port mismatch", cfg, "https://api.example.com:443/x", false, "http://proxy.corp:3128", "HTTPS_PROXY"},
+		{"NO_PROXY port match", cfg, "https://api.example.com:8443/x", false, "", "api.example.com:8443"},
+		{"no_proxy config entry", cfg, "https://www.partner.example.org", false, "", "no_proxy config entry"},
+		{"No proxy for plain HTTP", cfg, "http://api.example.com", false, "", "no proxy configured"},
+		{"Configured proxy wins", config.Config{Proxy: "http://cfg:8080"}, "http://api.example.com", false, "http://cfg:8080", "config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, err := resolveRoute(tt.cfg, tt.url, tt.bypass, getenv)
+			if err != nil {
+				t.Fatalf("resolveRoute() error = %v", err)
+			}
+			gotProxy := ""
+			if route.proxy != nil {
+				gotProxy = route.proxy.String()
+			}
+			if gotProxy != tt.wantProxy {
+				t.Errorf("proxy = %q, want %q", gotProxy, tt.wantProxy)
+			}
+			if !strings.Contains(route.reason, tt.wantReason) {
+				t.Errorf("reason = %q, want it to contain %q", route.reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+// TestSendRequestClosesConnections checks that the connection a request was sent on is
+// closed once its response has been read, rather than kept alive by a transport that is
+// never used again.
+func TestSendRequestClosesConnections(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed <- struct{}{}
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	if _, err := sendRequest(context.Background(), "GET", server.URL, nil, "", proxyRoute{}); err != nil {
+		t.Fatalf("sendRequest() error = %v", err)
+	}
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Error("connection still open after the response was read")
+	}
+}
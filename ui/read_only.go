@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// readOnlyMessage explains why an action was refused in read-only mode.
+const readOnlyMessage = "Read-only mode: sending requests and editing are disabled"
+
+// readOnlyRefuses reports whether msg triggers an action that sends a request or changes
+// the request, which read-only mode does not allow. It shows a toast when it does.
+func (a *App) readOnlyRefuses(msg tea.KeyMsg) bool {
+	if !a.config.ReadOnly {
+		return false
+	}
+
+	refused := []key.Binding{
+		a.keymap.FocusSubmit, a.keymap.Compare, a.keymap.AutoResend, a.keymap.LatencyBudget,
+		a.keymap.BypassProxy, a.keymap.SelectLocale, a.keymap.DataBinary, a.keymap.TimeTool,
+		a.keymap.PinEnvironment, a.keymap.EditEnvironments, a.keymap.DiscoverServices, a.keymap.RawEncoding,
+		a.keymap.WatchFiles, a.keymap.StepUp, a.keymap.StepDown, a.keymap.RecordSession,
+		a.keymap.ImportRequest, a.keymap.RecentRequests,
+	}
+	isAltSubmitRune := msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == '∞'
+	if key.Matches(msg, refused...) || isAltSubmitRune {
+		a.toast.Show(readOnlyMessage)
+		return true
+	}
+	return false
+}
+
+// readOnlyAllowsKey reports whether a key press may be passed to the focused component
+// in read-only mode. The Result tab can be browsed freely, while the form only accepts
+// keys that move the cursor, so long URLs and bodies can still be read.
+func (a *App) readOnlyAllowsKey(msg tea.KeyMsg) bool {
+	if !a.config.ReadOnly {
+		return true
+	}
+
+	switch {
+	case a.tabContainer.Active && a.tabContainer.ActiveTab == 1:
+		return true
+	case a.urlInput.Active:
+		switch msg.String() {
+		case "left", "right", "home", "end":
+			return true
+		}
+	case a.tabContainer.Active && a.tabContainer.ActiveTab == 0:
+		queryTab := a.tabContainer.GetQueryTab()
+		if queryTab.InnerTabs[queryTab.ActiveInnerTab] != "Body" {
+			return false
+		}
+		switch msg.String() {
+		case "up", "down", "left", "right", "pgup", "pgdown", "home", "end":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/request"
+	"github.com/RAshkettle/LazyPost/ui/components"
+)
+
+// maxRecentRequests is the number of requests kept in the recent list.
+const maxRecentRequests = 10
+
+// requestLabel identifies a request in the recent list.
+func requestLabel(r request.Request) string {
+	method := r.Method
+	if method == "" {
+		method = "GET"
+	}
+	return method + " " + r.URL
+}
+
+// requestKey identifies a request in the recent list by everything in it, so that requests
+// with the same method and URL but, e.g., another body are kept as separate entries.
+func requestKey(r request.Request) string {
+	data, _ := json.Marshal(r) // A Request holds only strings, numbers and maps of strings
+	return string(data)
+}
+
+// rememberRequest moves r to the front of the recent list, replacing an earlier
+// identical entry. Requests without a URL are not remembered.
+func (a *App) rememberRequest(r request.Request) {
+	if strings.TrimSpace(r.URL) == "" {
+		return
+	}
+
+	key := requestKey(r)
+	recent := []request.Request{r}
+	for _, existing := range a.recentRequests {
+		if requestKey(existing) != key && len(recent) < maxRecentRequests {
+			recent = append(recent, existing)
+		}
+	}
+	a.recentRequests = recent
+}
+
+// handleRecentRequests opens the recent requests switcher with the previous request
+// selected, so that pressing the key and Enter swaps between the last two requests.
+// Pressing the key again while the switcher is open moves to the next entry.
+func (a *App) handleRecentRequests() {
+	if a.picker.Visible && a.pickerMode == pickerRecent {
+		a.picker.Next()
+		return
+	}
+
+	// The form is the current "buffer"; keep it so it can be switched back to
+	current := a.snapshotRequest()
+	a.rememberRequest(current)
+
+	a.picker.Open("Recent requests", "")
+	a.pickerMode = pickerRecent
+
+	items := make([]components.PickerItem, 0, len(a.recentRequests))
+	currentListed := false
+	for i, r := range a.recentRequests {
+		label := requestLabel(r)
+		if id, ok := a.recentRequestIDs[requestKey(r)]; ok {
+			label += " • last ID " + shortRequestID(id)
+		}
+		if i == 0 && strings.TrimSpace(current.URL) != "" && requestKey(current) == requestKey(r) {
+			label += " (current)"
+			currentListed = true
+		}
+		items = append(items, components.PickerItem{Label: label, Value: strconv.Itoa(i)})
+	}
+	a.picker.SetItems(items, "No recent requests yet. Requests you send or import appear here.")
+	if currentListed {
+		a.picker.Next() // Preselect the previous request rather than the current one
+	}
+}
+
+// loadRecentRequest replaces the form with the recent request at the index held in value.
+func (a *App) loadRecentRequest(value string) {
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 0 || index >= len(a.recentRequests) {
+		return
+	}
+
+	if warnings := a.LoadRequest(a.recentRequests[index]); len(warnings) > 0 {
+		a.toast.Show("Loaded request with warnings:\n" + strings.Join(warnings, "\n"))
+		return
+	}
+	a.setFocus(focusURL)
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/request"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestRememberRequest tests the order of the recent list, that only identical requests
+// replace each other, that requests without a URL are skipped and that the list is capped.
+func TestRememberRequest(t *testing.T) {
+	get := request.Request{Method: "GET", URL: "https://api.example.com/items"}
+	post := request.Request{Method: "POST", URL: "https://api.example.com/items", Body: `{"id": 1}`}
+	otherBody := request.Request{Method: "POST", URL: "https://api.example.com/items", Body: `{"id": 2}`}
+
+	tests := []struct {
+		name     string
+		remember []request.Request
+		want     []request.Request
+	}{
+		{
+			name:     "most recent first",
+			remember: []request.Request{get, post},
+			want:     []request.Request{post, get},
+		},
+		{
+			name:     "identical request moves to the front",
+			remember: []request.Request{get, post, get},
+			want:     []request.Request{get, post},
+		},
+		{
+			name:     "same method and URL with another body is kept",
+			remember: []request.Request{post, otherBody},
+			want:     []request.Request{otherBody, post},
+		},
+		{
+			name:     "request without a URL is skipped",
+			remember: []request.Request{get, {Method: "GET", URL: "  "}},
+			want:     []request.Request{get},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := NewApp(config.Config{})
+			for _, r := range tt.remember {
+				app.rememberRequest(r)
+			}
+			if got, want := recentLabels(app.recentRequests), recentLabels(tt.want); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("recent requests = %q, want %q", got, want)
+			}
+			for i := range tt.want {
+				if requestKey(app.recentRequests[i]) != requestKey(tt.want[i]) {
+					t.Errorf("entry %d = %+v, want %+v", i, app.recentRequests[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("capped", func(t *testing.T) {
+		app := NewApp(config.Config{})
+		for i := range maxRecentRequests + 3 {
+			app.rememberRequest(request.Request{Method: "GET", URL: fmt.Sprintf("https://api.example.com/%d", i)})
+		}
+		if len(app.recentRequests) != maxRecentRequests {
+			t.Fatalf("kept %d requests, want %d", len(app.recentRequests), maxRecentRequests)
+		}
+		if got, want := app.recentRequests[maxRecentRequests-1].URL, "https://api.example.com/3"; got != want {
+			t.Errorf("oldest kept request = %q, want %q", got, want)
+		}
+	})
+}
+
+// TestRecentRequestsSwitcher tests that the switcher opens on the previous request, that
+// repeating its key moves to the next entry, and that choosing an entry loads it.
+func TestRecentRequestsSwitcher(t *testing.T) {
+	app := NewApp(config.Config{})
+	first := request.Request{Method: "GET", URL: "https://api.example.com/first"}
+	second := request.Request{Method: "GET", URL: "https://api.example.com/second"}
+	third := request.Request{Method: "DELETE", URL: "https://api.example.com/third"}
+	for _, r := range []request.Request{first, second, third} {
+		app.LoadRequest(r)
+	}
+	if len(app.recentRequests) != 3 {
+		t.Fatalf("loading kept %d requests, want 3: %q", len(app.recentRequests), recentLabels(app.recentRequests))
+	}
+
+	altO := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}, Alt: true}
+	app.Update(altO)
+	if !app.picker.Visible || len(app.picker.Items) != 3 {
+		t.Fatalf("switcher visible %v with %d items, want 3 items", app.picker.Visible, len(app.picker.Items))
+	}
+	if got := app.picker.Items[app.picker.Selected].Label; got != "GET https://api.example.com/second" {
+		t.Errorf("preselected %q, want the previous request", got)
+	}
+
+	app.Update(altO)
+	if got := app.picker.Items[app.picker.Selected].Label; got != "GET https://api.example.com/first" {
+		t.Errorf("repeating Alt+O selected %q, want the next entry", got)
+	}
+	app.Update(altO)
+	if got := app.picker.Items[app.picker.Selected].Label; got != "DELETE https://api.example.com/third (current)" {
+		t.Errorf("Alt+O on the last entry selected %q, want it to wrap to the current request", got)
+	}
+
+	app.Update(altO)
+	app.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := app.urlInput.GetText(); got != second.URL {
+		t.Errorf("URL after choosing an entry = %q, want %q", got, second.URL)
+	}
+}
+
+// recentLabels returns the recent list labels of requests.
+func recentLabels(requests []request.Request) []string {
+	var result []string
+	for _, r := range requests {
+		result = append(result, requestLabel(r))
+	}
+	return result
+}
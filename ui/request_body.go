@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/env"
+)
+
+// requestBody returns the body to send for the text entered in the Body tab. By default
+// trailing line breaks, which the editor easily leaves behind, are trimmed and {{name}}
+// placeholders are expanded. In data-binary mode the text is sent byte-for-byte as
+// entered, matching curl --data-binary.
+func requestBody(text string, e *env.Environment, dataBinary bool) string {
+	if dataBinary {
+		return text
+	}
+	return e.Expand(strings.TrimRight(text, "\r\n"))
+}
+
+// setBody loads text into the Body tab. The editor replaces tabs with spaces, turns
+// carriage returns into line breaks and drops other control bytes, so text is also kept
+// as it is, to be sent until the body is edited.
+func (a *App) setBody(text string) {
+	queryTab := a.tabContainer.GetQueryTab()
+	queryTab.SetBodyContent(text)
+	a.loadedBody = text
+	a.loadedBodyShown = queryTab.GetBodyContent()
+}
+
+// bodyText returns the body entered in the Body tab: the body as it was loaded, unless
+// the editor has been edited since.
+func (a *App) bodyText() string {
+	if shown := a.tabContainer.GetQueryTab().GetBodyContent(); shown != a.loadedBodyShown {
+		return shown
+	}
+	return a.loadedBody
+}
+
+// bodyEditedLossily reports whether the body was edited after loading text the editor
+// could not show exactly, so that it is no longer sent byte-for-byte as loaded.
+func (a *App) bodyEditedLossily() bool {
+	return a.loadedBody != a.loadedBodyShown && a.tabContainer.GetQueryTab().GetBodyContent() != a.loadedBodyShown
+}
+
+// handleToggleDataBinary switches between sending the body as entered and the default
+// handling of trailing line breaks and placeholders.
+func (a *App) handleToggleDataBinary() {
+	a.dataBinary = !a.dataBinary
+	if a.dataBinary {
+		a.toast.Show("Body is sent byte-for-byte as entered (--data-binary)")
+	} else {
+		a.toast.Show("Body is sent with placeholders expanded and trailing line breaks trimmed")
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/env"
+	"github.com/RAshkettle/LazyPost/fixtures"
+	"github.com/RAshkettle/LazyPost/request"
+)
+
+// TestRequestBody checks the default body handling against data-binary mode.
+func TestRequestBody(t *testing.T) {
+	e := &env.Environment{Variables: []env.Variable{{Name: "id", Value: "42"}}}
+	text := "{\"id\": {{id}}}\r\n\n"
+
+	if got := requestBody(text, e, false); got != `{"id": 42}` {
+		t.Errorf("default body = %q, want placeholders expanded and line breaks trimmed", got)
+	}
+	if got := requestBody(text, e, true); got != text {
+		t.Errorf("data-binary body = %q, want it unchanged", got)
+	}
+}
+
+// TestSendRequestBody checks that the body reaches the server byte-for-byte.
+func TestSendRequestBody(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	body := "line one\r\nline two\n\n"
+	if _, err := sendRequest(context.Background(), "POST", server.URL, nil, body, proxyRoute{}); err != nil {
+		t.Fatalf("sendRequest() error = %v", err)
+	}
+	if string(received) != body {
+		t.Errorf("server received %q, want %q", received, body)
+	}
+}
+
+// TestLoadedBodySentAsLoaded checks that a loaded body reaches the server in data-binary
+// mode with the carriage returns, tabs and control bytes the editor can't show, until the
+// body is edited.
+func TestLoadedBodySentAsLoaded(t *testing.T) {
+	server := fixtures.NewServer(t)
+	app := NewApp(config.Config{})
+	body := "{\r\n\t\"id\": 1\x01\r\n}\r\n"
+	app.LoadRequest(request.Request{Method: "POST", URL: server.URL + "/echo", Body: body, DataBinary: true})
+
+	send := func() string {
+		t.Helper()
+		prepared, err := app.prepareRequest(server.URL + "/echo")
+		if err != nil {
+			t.Fatalf("prepareRequest() error = %v", err)
+		}
+		if _, err := prepared.send(context.Background(), func(string) {}); err != nil {
+			t.Fatalf("send() error = %v", err)
+		}
+		received, _ := server.LastRequest()
+		return string(received.Body)
+	}
+
+	if shown := app.tabContainer.GetQueryTab().GetBodyContent(); shown == body {
+		t.Fatalf("editor shows the body unchanged, so the test proves nothing")
+	}
+	if got := send(); got != body {
+		t.Errorf("server received %q, want the body as loaded %q", got, body)
+	}
+	if app.bodyEditedLossily() {
+		t.Error("bodyEditedLossily() = true before the body was edited")
+	}
+
+	app.tabContainer.GetQueryTab().QueryBodyInput.InsertString("x")
+	edited := app.tabContainer.GetQueryTab().GetBodyContent()
+	if got := send(); got != edited {
+		t.Errorf("server received %q after editing, want the editor's content %q", got, edited)
+	}
+	if !app.bodyEditedLossily() {
+		t.Error("bodyEditedLossily() = false after the body was edited")
+	}
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+)
+
+// maxRedirects is how many redirects are followed before a request fails.
+const maxRedirects = 10
+
+// errTooManyRedirects is returned when a response redirects more than maxRedirects times.
+var errTooManyRedirects = fmt.Errorf("stopped after %d redirects", maxRedirects)
+
+// failureKind classifies why a request failed, so the user can be told what to check.
+type failureKind int
+
+const (
+	failureUnknown   failureKind = iota // Anything not recognized below
+	failureDNS                          // The host name could not be resolved
+	failureRefused                      // Nothing is listening on the host and port
+	failureTLS                          // The TLS handshake or certificate verification failed
+	failureTimeout                      // The server did not answer in time
+	failureCanceled                     // The request was canceled by the user
+	failureRedirects                    // The server kept redirecting
+)
+
+// requestError is a failed request along with its classification.
+type requestError struct {
+	kind failureKind // Why the request failed
+	err  error       // Underlying error as returned by the HTTP client
+}
+
+// Error returns the message of the underlying error.
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// checkRedirect is the http.Client redirect policy: redirects are followed up to maxRedirects.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return errTooManyRedirects
+	}
+	return nil
+}
+
+// classifyError wraps an error returned by the HTTP client in a requestError.
+func classifyError(err error) error {
+	return &requestError{kind: failureKindOf(err), err: err}
+}
+
+// failureKindOf determines why a request failed from the error chain.
+func failureKindOf(err error) failureKind {
+	var (
+		dnsErr       *net.DNSError
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+		recordErr    tls.RecordHeaderError
+		alertErr     tls.AlertError
+		netErr       net.Error
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return failureCanceled
+	case errors.Is(err, errTooManyRedirects):
+		return failureRedirects
+	case errors.As(err, &dnsErr):
+		return failureDNS
+	case errors.As(err, &verifyErr), errors.As(err, &authorityErr), errors.As(err, &hostnameErr),
+		errors.As(err, &invalidErr), errors.As(err, &recordErr), errors.As(err, &alertErr),
+		strings.Contains(err.Error(), "tls: "):
+		return failureTLS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return failureRefused
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return failureTimeout
+	}
+	return failureUnknown
+}
+
+// title returns a short description of the failure.
+func (k failureKind) title() string {
+	switch k {
+	case failureDNS:
+		return "Host not found"
+	case failureRefused:
+		return "Connection refused"
+	case failureTLS:
+		return "TLS error"
+	case failureTimeout:
+		return "Timed out"
+	case failureCanceled:
+		return "Canceled"
+	case failureRedirects:
+		return "Too many redirects"
+	}
+	return "Request failed"
+}
+
+// guidance returns what to check to fix the failure.
+func (k failureKind) guidance() []string {
+	switch k {
+	case failureDNS:
+		return []string{
+			"Check the host name in the URL for typos.",
+			"If it is an internal host, make sure you are on the right network or VPN.",
+			"An unresolved {{variable}} or a missing environment can also leave a bad host.",
+		}
+	case failureRefused:
+		return []string{
+			"Nothing accepted the connection on this host and port.",
+			"Check that the server is running and the port is correct (Alt+D lists local services).",
+			"If you use a proxy, check that it is running, or bypass it with Alt+B.",
+		}
+	case failureTLS:
+		return []string{
+			"The secure connection could not be established.",
+			"Check that the URL uses https:// only for servers that speak TLS on that port.",
+			"A self-signed or expired certificate, or one issued for another host name, is rejected.",
+		}
+	case failureTimeout:
+		return []string{
+			"The server or network did not respond in time.",
+			"Check that the host is reachable and not behind a firewall dropping packets.",
+			"If you use a proxy, check that it can reach the host.",
+		}
+	case failureCanceled:
+		return []string{"The request was canceled before a response arrived."}
+	case failureRedirects:
+		return []string{
+			fmt.Sprintf("The server redirected more than %d times, probably in a loop.", maxRedirects),
+			"Check the Location headers the server sends, e.g. http:// and https:// redirecting to each other.",
+		}
+	}
+	return []string{"See the error chain below for details."}
+}
+
+// errorChain returns the messages of err and every error it wraps, outermost first.
+// Each message has the text of the error it wraps removed, so every line adds something.
+func errorChain(err error) []string {
+	var chain []string
+	for err != nil {
+		next := errors.Unwrap(err)
+		message := err.Error()
+		if next != nil {
+			message = strings.TrimSuffix(message, ": "+next.Error())
+		}
+		if message != "" && (next == nil || message != next.Error()) {
+			chain = append(chain, message)
+		}
+		err = next
+	}
+	return chain
+}
+
+// formatRequestFailure renders a failed request for the Result view: what went wrong,
+// what to check and the full error chain.
+func formatRequestFailure(name string, err *requestError) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("%s %s\n\n", styles.HeaderNameStyle.Render(name+" failed:"), styles.DefaultTheme.ErrorStyle.Render(err.kind.title())))
+
+	for _, line := range err.kind.guidance() {
+		text.WriteString("  • " + line + "\n")
+	}
+
+	text.WriteString("\n" + styles.HeaderNameStyle.Render("Error chain:") + "\n")
+	for i, message := range errorChain(err.err) {
+		text.WriteString(fmt.Sprintf("  %d. %s\n", i+1, message))
+	}
+	return text.String()
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"syscall"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/fixtures"
+)
+
+// TestFailureKindOf checks the classification of errors as returned by the HTTP client.
+func TestFailureKindOf(t *testing.T) {
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://api.example.com", Err: err}
+	}
+	dialErr := func(err error) error {
+		return &net.OpError{Op: "dial", Net: "tcp", Err: err}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want failureKind
+	}{
+		{"DNS", urlErr(dialErr(&net.DNSError{Err: "no such host", Name: "api.example.com", IsNotFound: true})), failureDNS},
+		{"Connection refused", urlErr(dialErr(os.NewSyscallError("connect", syscall.ECONNREFUSED))), failureRefused},
+		{"Unknown authority", urlErr(x509.UnknownAuthorityError{}), failureTLS},
+		{"Deadline", urlErr(context.DeadlineExceeded), failureTimeout},
+		{"Canceled", urlErr(context.Canceled), failureCanceled},
+		{"Redirects", urlErr(errTooManyRedirects), failureRedirects},
+		{"Other", urlErr(errors.New("unexpected EOF")), failureUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := failureKindOf(tt.err); got != tt.want {
+				t.Errorf("failureKindOf(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestErrorChain checks that each level of the chain is listed once, without repeating the levels below it.
+func TestErrorChain(t *testing.T) {
+	err := &url.Error{Op: "Get", URL: "http://localhost:1", Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}
+
+	got := errorChain(err)
+	want := []string{`Get "http://localhost:1"`, "dial tcp", "connect", "connection refused"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("errorChain() = %q, want %q", got, want)
+	}
+}
+
+// TestSendRequestRedirectLoop checks that a redirect loop fails as too many redirects.
+func TestSendRequestRedirectLoop(t *testing.T) {
+	server := fixtures.NewServer(t)
+
+	_, err := sendRequest(context.Background(), "GET", server.URL+"/redirect-loop", nil, "", proxyRoute{})
+	var reqErr *requestError
+	if !errors.As(err, &reqErr) || reqErr.kind != failureRedirects {
+		t.Errorf("sendRequest() error = %v, want a too many redirects failure", err)
+	}
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/request"
+)
+
+// snapshotRequest captures the request currently entered in the form.
+func (a *App) snapshotRequest() request.Request {
+	queryTab := a.tabContainer.GetQueryTab()
+
+	r := request.Request{
+		Method:  a.methodSelector.GetSelectedMethod(),
+		URL:     a.urlInput.GetText(),
+		Params:  queryTab.ParamsInput.GetParams(),
+		Headers: queryTab.HeadersInput.GetHeaders(),
+		Auth:    request.Auth{Type: queryTab.AuthInput.GetAuthType()},
+		Body:    a.bodyText(),
+
+		LatencyBudgetMS: int(a.latencyBudget.Milliseconds()),
+		BypassProxy:     a.bypassProxy,
+		DataBinary:      a.dataBinary,
+		Environment:     a.pinnedEnvironment,
+		RawEncoding:     a.rawEncoding,
+	}
+
+	switch r.Auth.Type {
+	case "Basic":
+		r.Auth.Username, r.Auth.Password = queryTab.AuthInput.GetBasicCredentials()
+	case "Bearer":
+		r.Auth.Token = queryTab.AuthInput.GetBearerToken()
+	case "OAuth2":
+		r.Auth.Token = queryTab.AuthInput.GetOAuth2Token()
+	}
+	return r
+}
+
+// LoadRequest fills the form with r, replacing everything currently entered.
+// It returns warnings for parts of the request that could not be represented,
+// such as an unknown method or more headers than there are rows.
+func (a *App) LoadRequest(r request.Request) []string {
+	var warnings []string
+	queryTab := a.tabContainer.GetQueryTab()
+
+	if r.Method != "" && !a.methodSelector.SetSelectedMethod(r.Method) {
+		warnings = append(warnings, fmt.Sprintf("unsupported method %q", r.Method))
+	}
+	a.urlInput.SetText(r.URL)
+
+	if filled := queryTab.ParamsInput.SetParams(r.Params); filled < len(r.Params) {
+		warnings = append(warnings, fmt.Sprintf("%d params dropped (no free rows)", len(r.Params)-filled))
+	}
+	if filled := queryTab.HeadersInput.SetHeaders(r.Headers); filled < len(r.Headers) {
+		warnings = append(warnings, fmt.Sprintf("%d headers dropped (no free rows)", len(r.Headers)-filled))
+	}
+
+	authType := r.Auth.Type
+	if authType == "" {
+		authType = "None"
+	}
+	if !queryTab.AuthInput.SetAuthType(authType) {
+		warnings = append(warnings, fmt.Sprintf("unsupported auth type %q", authType))
+	}
+	queryTab.AuthInput.SetBasicCredentials(r.Auth.Username, r.Auth.Password)
+	queryTab.AuthInput.SetBearerToken(r.Auth.Token)
+	if authType == "OAuth2" {
+		queryTab.AuthInput.SetOAuth2Token(r.Auth.Token)
+	} else {
+		queryTab.AuthInput.SetOAuth2Token("")
+	}
+
+	a.setBody(r.Body)
+	a.latencyBudget = time.Duration(r.LatencyBudgetMS) * time.Millisecond
+	a.bypassProxy = r.BypassProxy
+	a.dataBinary = r.DataBinary
+	a.pinnedEnvironment = r.Environment
+	a.rawEncoding = r.RawEncoding
+	a.watch.remove(a.bodyFile)
+	a.bodyFile = ""
+
+	// Remembered as the form holds it, so that sending it unchanged does not add another entry
+	a.rememberRequest(a.snapshotRequest())
+	return warnings
+}
+
+// OpenRequest fills the form with r like LoadRequest and focuses the Submit button, so
+// that Enter sends the opened request. It returns LoadRequest's warnings.
+func (a *App) OpenRequest(r request.Request) []string {
+	warnings := a.LoadRequest(r)
+	a.setFocus(focusSubmit)
+	return warnings
+}
+
+// currentAuthToken returns the token sent in the Authorization header by the auth panel
+// (without its scheme), or an empty string if the selected auth type sends none.
+func (a *App) currentAuthToken() string {
+	authorization := a.tabContainer.GetQueryTab().AuthInput.GetAuthHeaders()["Authorization"]
+	if scheme, token, found := strings.Cut(authorization, " "); found && strings.EqualFold(scheme, "Bearer") {
+		return token
+	}
+	return ""
+}
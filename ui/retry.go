@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// retryTickMsg updates the Retry-After countdown. id ties it to the countdown that
+// scheduled it, so ticks of a replaced or cancelled countdown are ignored.
+type retryTickMsg struct {
+	id int
+}
+
+// retryAfter returns how long to wait before resending, for 429 and 503 responses
+// with a Retry-After header in either delay-seconds or HTTP-date form.
+func retryAfter(statusCode int, header http.Header, now time.Time) (time.Duration, bool) {
+	if statusCode != http.StatusTooManyRequests && statusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// startRetryCountdown starts counting down to the end of the Retry-After window.
+func (a *App) startRetryCountdown(wait time.Duration) tea.Cmd {
+	a.retryID++
+	a.retryAt = time.Now().Add(wait)
+	a.updateRetryNotice()
+	return a.scheduleRetryTick()
+}
+
+// cancelRetryCountdown stops any running countdown and clears its notice.
+func (a *App) cancelRetryCountdown() {
+	a.retryID++
+	a.retryAt = time.Time{}
+	a.tabContainer.GetResultTab().SetNotice("")
+}
+
+// scheduleRetryTick schedules the next countdown update.
+func (a *App) scheduleRetryTick() tea.Cmd {
+	id := a.retryID
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return retryTickMsg{id: id}
+	})
+}
+
+// handleRetryTick updates the countdown and, when the window has elapsed,
+// resends the request if auto-resend is on.
+func (a *App) handleRetryTick(msg retryTickMsg) tea.Cmd {
+	if msg.id != a.retryID || a.retryAt.IsZero() {
+		return nil
+	}
+
+	if time.Now().Before(a.retryAt) {
+		a.updateRetryNotice()
+		return a.scheduleRetryTick()
+	}
+
+	a.retryAt = time.Time{}
+	if a.autoResend {
+		a.tabContainer.GetResultTab().SetNotice("")
+		cmd := a.handleSubmit()
+		a.keepQueryFocus = true // Resent in the background, so do not interrupt editing
+		return cmd
+	}
+	a.tabContainer.GetResultTab().SetNotice("Retry window elapsed • Alt+5 to resend")
+	return nil
+}
+
+// handleToggleAutoResend switches automatic resending at the end of a Retry-After window.
+func (a *App) handleToggleAutoResend() {
+	a.autoResend = !a.autoResend
+	if !a.retryAt.IsZero() {
+		a.updateRetryNotice()
+		return
+	}
+
+	state := "off"
+	if a.autoResend {
+		state = "on"
+	}
+	a.toast.Show(fmt.Sprintf("Auto-resend after Retry-After is %s", state))
+}
+
+// updateRetryNotice shows the remaining wait next to the result tabs.
+func (a *App) updateRetryNotice() {
+	remaining := time.Until(a.retryAt).Round(time.Second)
+	action := "Alt+R: auto-resend"
+	if a.autoResend {
+		action = "auto-resend on (Alt+R: off)"
+	}
+	a.tabContainer.GetResultTab().SetNotice(fmt.Sprintf("Retry in %s • %s", remaining, action))
+}
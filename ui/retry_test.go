@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestRetryAfter tests parsing Retry-After in both forms and ignoring other statuses.
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		statusCode int
+		value      string
+		expected   time.Duration
+		ok         bool
+	}{
+		{name: "Seconds", statusCode: http.StatusTooManyRequests, value: "30", expected: 30 * time.Second, ok: true},
+		{name: "HTTP date", statusCode: http.StatusServiceUnavailable, value: "Wed, 01 Jan 2025 12:01:00 GMT", expected: time.Minute, ok: true},
+		{name: "Date in the past", statusCode: http.StatusTooManyRequests, value: "Wed, 01 Jan 2025 11:00:00 GMT", expected: 0, ok: true},
+		{name: "Missing header", statusCode: http.StatusTooManyRequests, value: "", ok: false},
+		{name: "Invalid value", statusCode: http.StatusTooManyRequests, value: "soon", ok: false},
+		{name: "Other status", statusCode: http.StatusOK, value: "30", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			got, ok := retryAfter(tt.statusCode, header, now)
+			if ok != tt.ok || got != tt.expected {
+				t.Errorf("retryAfter() = %v, %v, expected %v, %v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
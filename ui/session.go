@@ -0,0 +1,283 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/redact"
+	"github.com/RAshkettle/LazyPost/ui/components"
+	"github.com/RAshkettle/LazyPost/vault"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sessionStep is a request sent while a session was being recorded.
+type sessionStep struct {
+	prepared preparedRequest // The request as it was sent, with variables expanded
+	status   int             // Status code of its response, 0 if none arrived
+}
+
+// sessionRecording is the sequence of requests sent since recording started.
+type sessionRecording struct {
+	started time.Time     // When recording started
+	steps   []sessionStep // Requests in the order they were sent
+}
+
+// handleToggleRecording starts recording the requests sent from the form or, when a
+// recording is running, stops it and exports it as a replay script.
+func (a *App) handleToggleRecording() tea.Cmd {
+	if a.recording == nil {
+		a.recording = &sessionRecording{started: time.Now()}
+		a.toast.Show("Recording session: requests you send are added to a replay script, Alt+K stops and exports it")
+		return nil
+	}
+
+	recording := *a.recording
+	a.recording = nil
+	if len(recording.steps) == 0 {
+		a.toast.Show("Stopped recording: no requests were sent")
+		return nil
+	}
+
+	script := formatSessionScript(recording, a.privacyRedactor)
+	name := "lazypost-session-" + time.Now().Format("20060102-150405") + ".sh"
+
+	return a.jobs.start(job{name: "Session export", run: func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		if err := os.WriteFile(name, []byte(script), 0o700); err != nil {
+			return components.ShowToastMsg{Message: fmt.Sprintf("Error exporting session: %v", err)}, nil
+		}
+		return components.ShowToastMsg{Message: fmt.Sprintf("Exported %d requests to %s", len(recording.steps), name)}, nil
+	}})
+}
+
+// recordStep adds a request that is being sent to the recording, if one is running.
+func (a *App) recordStep(prepared preparedRequest) {
+	if a.recording == nil {
+		return
+	}
+	a.recording.steps = append(a.recording.steps, sessionStep{prepared: prepared})
+}
+
+// recordResponse stores the status code of a response with the last recorded request,
+// unless that request already has one.
+func (a *App) recordResponse(status int) {
+	if a.recording == nil || len(a.recording.steps) == 0 {
+		return
+	}
+	last := &a.recording.steps[len(a.recording.steps)-1]
+	if last.status == 0 {
+		last.status = status
+	}
+}
+
+// sessionStepFunc is the shell function each replayed request runs through. It sends the
+// request with curl, with globbing off so that brackets and braces in URLs are sent as they
+// are, and fails the script when the status differs from the recorded one, which is "-"
+// when no response was recorded.
+const sessionStepFunc = `step() {
+	local number=$1 want=$2
+	shift 2
+	local got
+	got=$(curl -sS --globoff -o /dev/null -w '%{http_code}' "$@")
+	if [ "$want" != "-" ] && [ "$got" != "$want" ]; then
+		echo "step $number: expected status $want, got $got" >&2
+		exit 1
+	fi
+	echo "step $number: $got"
+}
+`
+
+// formatSessionScript renders recording as a bash script that replays its requests with
+// curl in order, checking each response status against the recorded one. Secrets are not
+// written out: the script reads them from environment variables and refuses to run without
+// them. Values of the headers redactor considers sensitive are read from a variable named
+// after the header, e.g. HEADER_AUTHORIZATION, values of secret environment variables from
+// one named after the variable, e.g. VAR_API_KEY, and Vault secrets from one named after the
+// secret, e.g. VAULT_KV_API_TOKEN. The prefixes keep a header and a variable with the same
+// name apart. Correlation IDs are left out, since a replay should not reuse them.
+func formatSessionScript(recording sessionRecording, redactor *redact.Redactor) string {
+	var declarations []string
+	required := map[string]bool{}
+	require := func(variable, description string) {
+		if !required[variable] {
+			required[variable] = true
+			declarations = append(declarations, fmt.Sprintf(": \"${%s:?set %s to %s}\"\n", variable, variable, description))
+		}
+	}
+
+	var steps strings.Builder
+	for i, step := range recording.steps {
+		p := step.prepared
+		want := "-"
+		if step.status != 0 {
+			want = strconv.Itoa(step.status)
+		}
+
+		// Secrets that were expanded into the request are replaced by the variables they are read from
+		var secretReferences []shellReference
+		descriptions := map[string]string{}
+		for name, value := range p.secrets {
+			variable := shellVariable("var_" + name)
+			descriptions[variable] = "the value of the secret variable " + name
+			secretReferences = append(secretReferences, urlForms(value, variable)...)
+		}
+		references := slices.Clone(secretReferences)
+		for _, text := range slices.Concat([]string{p.finalURL}, slices.Collect(maps.Values(p.headers))) {
+			for _, placeholder := range vault.Placeholders(text) {
+				references = append(references, vaultReference(placeholder, descriptions)...)
+			}
+		}
+		// A body sent as entered keeps its Vault placeholders
+		bodyReferences := slices.Clone(secretReferences)
+		if !p.dataBinary {
+			for _, placeholder := range vault.Placeholders(p.body) {
+				bodyReferences = append(bodyReferences, vaultReference(placeholder, descriptions)...)
+			}
+		}
+		word := func(s string, references []shellReference) string {
+			quoted, used := shellWord(s, references)
+			for _, variable := range used {
+				require(variable, descriptions[variable])
+			}
+			return quoted
+		}
+
+		fmt.Fprintf(&steps, "\n# %d. %s %s\n", i+1, p.method, maskSecretValues(p.finalURL, p.secrets, redact.Placeholder))
+		if step.status == 0 {
+			steps.WriteString("# No response was recorded: the request failed or was canceled.\n")
+		}
+		if p.method == http.MethodHead {
+			fmt.Fprintf(&steps, "step %d %s --head %s", i+1, want, word(p.finalURL, references))
+		} else {
+			fmt.Fprintf(&steps, "step %d %s -X %s %s", i+1, want, shellQuote(p.method), word(p.finalURL, references))
+		}
+
+		names := make([]string, 0, len(p.headers))
+		for name := range p.headers {
+			if p.requestID != "" && http.CanonicalHeaderKey(name) == p.requestIDHeader {
+				continue
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if !redactor.IsSensitiveHeader(name) {
+				fmt.Fprintf(&steps, " \\\n\t-H %s", word(name+": "+p.headers[name], references))
+				continue
+			}
+			variable := shellVariable("header_" + name)
+			require(variable, "the "+name+" header value")
+			fmt.Fprintf(&steps, " \\\n\t-H %s\"${%s}\"", shellQuote(name+": "), variable)
+		}
+		if p.body != "" {
+			fmt.Fprintf(&steps, " \\\n\t--data-binary %s", word(p.body, bodyReferences))
+		}
+		steps.WriteString("\n")
+	}
+
+	var script strings.Builder
+	script.WriteString("#!/usr/bin/env bash\n")
+	fmt.Fprintf(&script, "# LazyPost session recorded %s, %d requests.\n", recording.started.Format("2006-01-02 15:04:05"), len(recording.steps))
+	script.WriteString("# Replays each request with curl and stops when a response status differs from the recorded one.\n")
+	script.WriteString("set -euo pipefail\n")
+	if len(declarations) > 0 {
+		script.WriteString("\n# Sensitive headers, secret variables and Vault secrets are read from the environment.\n")
+		script.WriteString(strings.Join(declarations, ""))
+	}
+	script.WriteString("\n" + sessionStepFunc)
+	script.WriteString(steps.String())
+	return script.String()
+}
+
+// shellReference is text of a recorded request that the replay script reads from a variable.
+type shellReference struct {
+	text     string // Text as it appears in the request
+	variable string // Variable the script substitutes for it
+}
+
+// urlForms returns references from value to variable, also for the forms value takes when
+// it is URL-encoded into a query or path. The variable holds the value as it is, so curl
+// sends it unencoded.
+func urlForms(value, variable string) []shellReference {
+	var references []shellReference
+	for _, form := range []string{value, url.QueryEscape(value), url.PathEscape(value)} {
+		if !slices.ContainsFunc(references, func(r shellReference) bool { return r.text == form }) {
+			references = append(references, shellReference{text: form, variable: variable})
+		}
+	}
+	return references
+}
+
+// vaultReference returns references from a Vault placeholder to the variable its secret is
+// read from, e.g. VAULT_KV_API_TOKEN for {{vault:kv/api#token}}, and describes the variable
+// in descriptions.
+func vaultReference(placeholder string, descriptions map[string]string) []shellReference {
+	secret := strings.TrimSuffix(strings.TrimPrefix(placeholder, "{{vault:"), "}}")
+	variable := shellVariable("vault_" + secret)
+	descriptions[variable] = "the Vault secret " + secret
+	return urlForms(placeholder, variable)
+}
+
+// shellWord quotes s as a single bash word in which every occurrence of the text of a
+// reference is replaced by its variable, and returns the variables it used. Longer texts
+// are matched first where several start at the same place.
+func shellWord(s string, references []shellReference) (string, []string) {
+	var word strings.Builder
+	var used []string
+	for s != "" {
+		start, match := -1, shellReference{}
+		for _, reference := range references {
+			if reference.text == "" {
+				continue
+			}
+			i := strings.Index(s, reference.text)
+			if i >= 0 && (start < 0 || i < start || (i == start && len(reference.text) > len(match.text))) {
+				start, match = i, reference
+			}
+		}
+		if start < 0 {
+			word.WriteString(shellQuote(s))
+			break
+		}
+		if start > 0 {
+			word.WriteString(shellQuote(s[:start]))
+		}
+		word.WriteString("\"${" + match.variable + "}\"")
+		if !slices.Contains(used, match.variable) {
+			used = append(used, match.variable)
+		}
+		s = s[start+len(match.text):]
+	}
+	if word.Len() == 0 {
+		return "''", used
+	}
+	return word.String(), used
+}
+
+// shellQuote quotes s as a single bash word, without expanding anything in it.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellVariable returns the name of an environment variable for name: name in upper case,
+// with characters a variable name can't hold replaced by "_".
+func shellVariable(name string) string {
+	variable := []byte(strings.ToUpper(name))
+	for i, c := range variable {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			variable[i] = '_'
+		}
+	}
+	if len(variable) == 0 || (variable[0] >= '0' && variable[0] <= '9') {
+		variable = append([]byte{'_'}, variable...)
+	}
+	return string(variable)
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/config"
+	"github.com/RAshkettle/LazyPost/env"
+	"github.com/RAshkettle/LazyPost/fixtures"
+	"github.com/RAshkettle/LazyPost/redact"
+)
+
+// TestSessionReplay tests that a recorded session is exported as a script that replays
+// its requests against the same server, with secrets read from the environment.
+func TestSessionReplay(t *testing.T) {
+	for _, tool := range []string{"bash", "curl"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not installed", tool)
+		}
+	}
+	server := fixtures.NewServer(t)
+	app := NewApp(config.Config{RequestIDHeader: "X-Request-ID"})
+	app.handleToggleRecording()
+
+	send := func(method, path, body string, headers map[string]string) {
+		t.Helper()
+		app.methodSelector.SetSelectedMethod(method)
+		app.tabContainer.GetQueryTab().HeadersInput.SetHeaders(headers)
+		app.tabContainer.GetQueryTab().QueryBodyInput.SetValue(body)
+		prepared, err := app.prepareRequest(server.URL + path)
+		if err != nil {
+			t.Fatalf("prepareRequest() error = %v", err)
+		}
+		app.recordStep(prepared)
+		msg, err := prepared.send(context.Background(), func(string) {})
+		if err != nil {
+			t.Fatalf("send() error = %v", err)
+		}
+		app.Update(msg)
+	}
+	send("GET", "/bearer/s3cret", "", map[string]string{"Authorization": "Bearer s3cret"})
+	send("POST", "/echo", `{"name": "O'Brien"}`, map[string]string{"Content-Type": "application/json"})
+	send("GET", "/status/404", "", nil)
+	send("GET", "/items[0]", "", nil)
+
+	if got := app.recording.steps[2].status; got != 404 {
+		t.Errorf("recorded status %d for the last request, want 404", got)
+	}
+	script := formatSessionScript(*app.recording, app.privacyRedactor)
+	if strings.Contains(script, "Bearer s3cret") {
+		t.Errorf("script contains the bearer token:\n%s", script)
+	}
+	if strings.Contains(script, "X-Request-Id") {
+		t.Errorf("script reuses the recorded correlation ID:\n%s", script)
+	}
+
+	path := filepath.Join(t.TempDir(), "session.sh")
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	recorded := len(server.Requests())
+
+	if out, err := exec.Command("bash", path).CombinedOutput(); err == nil {
+		t.Errorf("script ran without HEADER_AUTHORIZATION set:\n%s", out)
+	}
+
+	cmd := exec.Command("bash", path)
+	cmd.Env = append(os.Environ(), "HEADER_AUTHORIZATION=Bearer s3cret")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("replay failed: %v\n%s\nscript:\n%s", err, out, script)
+	}
+	if want := "step 1: 200\nstep 2: 200\nstep 3: 404\nstep 4: 404\n"; string(out) != want {
+		t.Errorf("replay output = %q, want %q", out, want)
+	}
+
+	replayed := server.Requests()[recorded:]
+	if len(replayed) != 4 {
+		t.Fatalf("server got %d replayed requests, want 4", len(replayed))
+	}
+	if got := string(replayed[1].Body); got != `{"name": "O'Brien"}` {
+		t.Errorf("replayed body = %q, want the recorded one", got)
+	}
+	if got := replayed[3].Path; got != "/items[0]" {
+		t.Errorf("replayed path = %q, want the brackets sent as recorded", got)
+	}
+}
+
+// TestSessionReplaySecrets tests that secret variables expanded into the URL and body, and
+// Vault placeholders, are read from the environment by the script instead of written to it.
+func TestSessionReplaySecrets(t *testing.T) {
+	for _, tool := range []string{"bash", "curl"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not installed", tool)
+		}
+	}
+	server := fixtures.NewServer(t)
+	app := NewApp(config.Config{})
+	app.SetEnvironments(env.Store{Active: "dev", Environments: []env.Environment{{
+		Name:      "dev",
+		Variables: []env.Variable{{Name: "api_key", Value: "k3y-value", Secret: true}},
+	}}}, "")
+	app.handleToggleRecording()
+
+	app.methodSelector.SetSelectedMethod("POST")
+	app.tabContainer.GetQueryTab().HeadersInput.SetHeaders(map[string]string{"X-Trace": "{{vault:kv/api#token}}"})
+	app.tabContainer.GetQueryTab().QueryBodyInput.SetValue(`{"key": "{{api_key}}"}`)
+	prepared, err := app.prepareRequest(app.requestEnvironment().Expand(server.URL + "/echo?key={{api_key}}"))
+	if err != nil {
+		t.Fatalf("prepareRequest() error = %v", err)
+	}
+	app.recordStep(prepared)
+	app.recordResponse(200)
+
+	script := formatSessionScript(*app.recording, app.privacyRedactor)
+	for _, secret := range []string{"k3y-value", "{{vault:"} {
+		if strings.Contains(script, secret) {
+			t.Errorf("script contains %q:\n%s", secret, script)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "session.sh")
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("bash", path)
+	cmd.Env = append(os.Environ(), "VAR_API_KEY=k3y-value", "VAULT_KV_API_TOKEN=t0ken")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("replay failed: %v\n%s\nscript:\n%s", err, out, script)
+	}
+
+	replayed, ok := server.LastRequest()
+	if !ok {
+		t.Fatal("server got no replayed request")
+	}
+	if replayed.Query != "key=k3y-value" || string(replayed.Body) != `{"key": "k3y-value"}` || replayed.Header.Get("X-Trace") != "t0ken" {
+		t.Errorf("replayed query %q, body %q and X-Trace %q, want the secrets from the environment", replayed.Query, replayed.Body, replayed.Header.Get("X-Trace"))
+	}
+}
+
+// TestShellVariable tests the names of the variables secrets are read from.
+func TestShellVariable(t *testing.T) {
+	tests := map[string]string{
+		"header_Authorization": "HEADER_AUTHORIZATION",
+		"var_api.key":          "VAR_API_KEY",
+		"1st-Token":            "_1ST_TOKEN",
+	}
+	for name, want := range tests {
+		if got := shellVariable(name); got != want {
+			t.Errorf("shellVariable(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// TestSessionScriptVariableNames tests that a secret variable and a sensitive header with the
+// same name are read from different variables.
+func TestSessionScriptVariableNames(t *testing.T) {
+	redactor, err := redact.New(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := formatSessionScript(sessionRecording{steps: []sessionStep{{
+		prepared: preparedRequest{
+			method:   "GET",
+			finalURL: "https://api.example.invalid/items?key=v4r-secret",
+			headers:  map[string]string{"X-Api-Key": "h34der-secret"},
+			secrets:  map[string]string{"x_api_key": "v4r-secret"},
+		},
+		status: 200,
+	}}}, redactor)
+
+	for _, expected := range []string{`key='"${VAR_X_API_KEY}"`, `-H 'X-Api-Key: '"${HEADER_X_API_KEY}"`} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("script does not contain %s:\n%s", expected, script)
+		}
+	}
+}
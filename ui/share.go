@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/request"
+	"github.com/RAshkettle/LazyPost/ui/components"
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleExportRequest saves the current request to a timestamped JSON file in the
+// working directory and copies its shareable string form to the clipboard.
+// Credentials are redacted according to the configured redaction rules.
+func (a *App) handleExportRequest() tea.Cmd {
+	r := a.redactor.Request(a.snapshotRequest())
+	name := "lazypost-request-" + time.Now().Format("20060102-150405") + ".json"
+
+	return a.jobs.start(job{name: "Request export", run: func(ctx context.Context, progress func(string)) (tea.Msg, error) {
+		if err := request.SaveFile(name, r); err != nil {
+			return components.ShowToastMsg{Message: fmt.Sprintf("Error exporting request: %v", err)}, nil
+		}
+
+		encoded, err := r.Encode()
+		if err == nil {
+			err = clipboard.WriteAll(encoded)
+		}
+		if err != nil {
+			return components.ShowToastMsg{Message: fmt.Sprintf("Exported request to %s (clipboard copy failed: %v)", name, err)}, nil
+		}
+		return components.ShowToastMsg{Message: fmt.Sprintf("Exported request to %s\nShare string copied to clipboard", name)}, nil
+	}})
+}
+
+// handleImportRequest loads a request from the clipboard, which may hold either
+// a shared request string or request JSON, and replaces the form contents with it.
+func (a *App) handleImportRequest() {
+	text, err := clipboard.ReadAll()
+	if err != nil {
+		a.toast.Show(fmt.Sprintf("Error reading clipboard: %v", err))
+		return
+	}
+
+	r, err := request.Decode(text)
+	if err != nil {
+		a.toast.Show(fmt.Sprintf("Error importing request: %v", err))
+		return
+	}
+
+	if warnings := a.LoadRequest(r); len(warnings) > 0 {
+		a.toast.Show("Imported request with warnings:\n" + strings.Join(warnings, "\n"))
+		return
+	}
+	a.setFocus(focusURL)
+}
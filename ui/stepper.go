@@ -0,0 +1,18 @@
+package ui
+
+// handleStep adds delta to the number or date at the cursor of the focused URL, parameter
+// value or header value, so values can be tweaked between sends without retyping them.
+func (a *App) handleStep(delta int) {
+	stepped := false
+	if a.urlInput.Active {
+		stepped = a.urlInput.Step(delta)
+	} else if a.tabContainer.Active && a.tabContainer.ActiveTab == 0 {
+		stepped = a.tabContainer.QueryTab.StepFocused(delta)
+	} else {
+		a.toast.Show("Focus the URL, a parameter value or a header value to step a number")
+		return
+	}
+	if !stepped {
+		a.toast.Show("No number or date at the cursor to step")
+	}
+}
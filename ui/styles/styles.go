@@ -4,78 +4,201 @@ package styles
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
-// Common styling constants used throughout the application
+// Palette holds the base colors that all styles are built from.
+// Swapping the palette (see UsePalette) rebuilds every style and DefaultTheme.
+type Palette struct {
+	Primary   lipgloss.Color // Active borders and highlights
+	Highlight lipgloss.Color // Selected items and help text
+	Secondary lipgloss.Color // General text and inactive borders
+	URL       lipgloss.Color // URL elements
+	Method    lipgloss.Color // Method elements
+	Error     lipgloss.Color // Error messages
+	HelpText  lipgloss.Color // Help text
+}
+
+// TrueColorPalette uses hex colors. It is used on truecolor and 256-color terminals,
+// where lipgloss maps each hex value to the closest available color.
+var TrueColorPalette = Palette{
+	Primary:   lipgloss.Color("#00FF00"), // Green for active borders
+	Highlight: lipgloss.Color("#FFFF00"), // Bright yellow for selected method
+	Secondary: lipgloss.Color("#FFFFFF"), // White for general text and inactive borders
+	URL:       lipgloss.Color("#00BFFF"), // Bright blue color for URL elements
+	Method:    lipgloss.Color("#00BFFF"), // Blue color for Method elements
+	Error:     lipgloss.Color("#FF0000"), // Red for error messages
+	HelpText:  lipgloss.Color("#E5C07B"), // Yellow for help text
+}
+
+// ANSIPalette uses the 16 basic ANSI colors, for terminals without 256-color support.
+// The terminal's own color scheme decides the exact shades.
+var ANSIPalette = Palette{
+	Primary:   lipgloss.Color("10"), // Bright green
+	Highlight: lipgloss.Color("11"), // Bright yellow
+	Secondary: lipgloss.Color("15"), // Bright white
+	URL:       lipgloss.Color("14"), // Bright cyan
+	Method:    lipgloss.Color("14"), // Bright cyan
+	Error:     lipgloss.Color("9"),  // Bright red
+	HelpText:  lipgloss.Color("3"),  // Yellow
+}
+
+// Common styling constants used throughout the application.
+// They are (re)built from the active palette by buildStyles.
 var (
 	// Colors
-	PrimaryColor   = lipgloss.Color("#00FF00") // Green for active borders
-	BrightYellow   = lipgloss.Color("#FFFF00") // Bright yellow for selected method
-	SecondaryColor = lipgloss.Color("#FFFFFF") // White for general text and inactive borders
-	URLColor       = lipgloss.Color("#00BFFF") // Bright blue color for URL elements
-	MethodColor    = lipgloss.Color("#00BFFF") // Blue color for Method elements
-	ErrorColor     = lipgloss.Color("#FF0000") // Red for error messages
+	PrimaryColor   lipgloss.Color // Green for active borders
+	BrightYellow   lipgloss.Color // Bright yellow for selected method
+	SecondaryColor lipgloss.Color // White for general text and inactive borders
+	URLColor       lipgloss.Color // Bright blue color for URL elements
+	MethodColor    lipgloss.Color // Blue color for Method elements
+	ErrorColor     lipgloss.Color // Red for error messages
 
 	// Border Styles
 	// Standard border style for inactive components
+	BorderStyle lipgloss.Style
+
+	// Border style for active/focused components
+	ActiveBorderStyle lipgloss.Style
+
+	// Text Styles
+	// General title style for components
+	TitleStyle lipgloss.Style
+
+	// Title style specific for URL components
+	URLTitleStyle lipgloss.Style
+
+	// Title style specific for Method components
+	MethodTitleStyle lipgloss.Style
+
+	// Style for selected items in lists or dropdowns
+	SelectedItemStyle lipgloss.Style
+
+	// Style for general input fields (active state)
+	ActiveInputStyle lipgloss.Style
+
+	// Style for general input fields (inactive state)
+	InactiveInputStyle lipgloss.Style
+
+	// Style for the items in an open dropdown
+	DropdownItemStyle lipgloss.Style
+
+	// Style for the currently highlighted item in an open dropdown
+	DropdownSelectedItemStyle lipgloss.Style
+
+	// Style for containers holding inputs or other components
+	InputContainerStyle lipgloss.Style
+
+	// Style for text within a dropdown
+	DropdownTextStyle lipgloss.Style
+
+	// Style for the dropdown arrow
+	DropdownArrowStyle lipgloss.Style
+
+	// Style for header names in the response headers view
+	HeaderNameStyle lipgloss.Style
+
+	// Create warning style
+	ToastStyle lipgloss.Style
+)
+
+// Theme struct to hold all application styles
+type Theme struct {
+	PrimaryColor              lipgloss.Color
+	SecondaryColor            lipgloss.Color
+	URLColor                  lipgloss.Color
+	MethodColor               lipgloss.Color
+	ErrorColor                lipgloss.Color
+	BrightYellow              lipgloss.Color
+	BorderStyle               lipgloss.Style
+	ActiveBorderStyle         lipgloss.Style
+	TitleStyle                lipgloss.Style
+	URLTitleStyle             lipgloss.Style
+	MethodTitleStyle          lipgloss.Style
+	SelectedItemStyle         lipgloss.Style
+	ActiveInputStyle          lipgloss.Style
+	InactiveInputStyle        lipgloss.Style
+	DropdownItemStyle         lipgloss.Style // New style for dropdown items
+	DropdownSelectedItemStyle lipgloss.Style // New style for selected dropdown items
+	InputContainerStyle       lipgloss.Style
+	DropdownTextStyle         lipgloss.Style
+	DropdownArrowStyle        lipgloss.Style
+	ToastStyle                lipgloss.Style
+
+	// New fields for additional colors and styles
+	HelpTextColor lipgloss.Color // Color for help text
+	ErrorStyle    lipgloss.Style
+	SuccessStyle  lipgloss.Style
+	SpinnerStyle  lipgloss.Style
+	HelpTextStyle lipgloss.Style // New style for help text
+}
+
+// DefaultTheme is the instance of Theme with default styles
+var DefaultTheme Theme
+
+func init() {
+	buildStyles(TrueColorPalette)
+}
+
+// buildStyles sets the package colors, styles and DefaultTheme from the given palette.
+func buildStyles(p Palette) {
+	// Colors
+	PrimaryColor = p.Primary
+	BrightYellow = p.Highlight
+	SecondaryColor = p.Secondary
+	URLColor = p.URL
+	MethodColor = p.Method
+	ErrorColor = p.Error
+
 	BorderStyle = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(SecondaryColor)
 
-	// Border style for active/focused components
-	ActiveBorderStyle = BorderStyle.Copy(). // Use Copy() to avoid modifying the original
+	// Use Copy() to avoid modifying the original
+	ActiveBorderStyle = BorderStyle.Copy().
 		BorderForeground(PrimaryColor)
 
-	// Text Styles
-	// General title style for components
 	TitleStyle = lipgloss.NewStyle().
 		Foreground(SecondaryColor).
 		Bold(true)
 
-	// Title style specific for URL components
 	URLTitleStyle = lipgloss.NewStyle().
 		Foreground(URLColor).
 		Bold(true)
 
-	// Title style specific for Method components
 	MethodTitleStyle = lipgloss.NewStyle().
 		Foreground(MethodColor).
 		Bold(true)
 
-	// Style for selected items in lists or dropdowns
 	SelectedItemStyle = lipgloss.NewStyle().
 		Foreground(BrightYellow).
 		Bold(true)
 
-	// Style for general input fields (active state)
 	ActiveInputStyle = ActiveBorderStyle.Copy().
 		Padding(0, 1) // Add some horizontal padding for text inside input
 
-	// Style for general input fields (inactive state)
 	InactiveInputStyle = BorderStyle.Copy().
 		Padding(0, 1) // Add some horizontal padding for text inside input
 
-	// Style for the items in an open dropdown
 	DropdownItemStyle = lipgloss.NewStyle().
 		Padding(0, 1) // Add some horizontal padding
 
-	// Style for the currently highlighted item in an open dropdown
 	DropdownSelectedItemStyle = DropdownItemStyle.Copy().
 		Background(PrimaryColor).
 		Foreground(SecondaryColor)
 
-	// Style for containers holding inputs or other components
 	InputContainerStyle = BorderStyle.Copy()
 
-	// Style for text within a dropdown
 	DropdownTextStyle = lipgloss.NewStyle().
 		Foreground(SecondaryColor)
 
-	// Style for the dropdown arrow
 	DropdownArrowStyle = lipgloss.NewStyle().
 		Foreground(SecondaryColor)
 
-	// Create warning style
+	HeaderNameStyle = lipgloss.NewStyle().
+		Foreground(BrightYellow).
+		Bold(true)
+
 	ToastStyle = lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("#FFD700")). // Gold border
@@ -85,66 +208,65 @@ var (
 		Align(lipgloss.Center, lipgloss.Center).     // Center content
 		Bold(true)                                   // Make the text bold
 
-)
+	DefaultTheme = Theme{
+		PrimaryColor:              PrimaryColor,
+		SecondaryColor:            SecondaryColor,
+		URLColor:                  URLColor,
+		MethodColor:               MethodColor,
+		ErrorColor:                ErrorColor,
+		BrightYellow:              BrightYellow,
+		BorderStyle:               BorderStyle,
+		ActiveBorderStyle:         ActiveBorderStyle,
+		TitleStyle:                TitleStyle,
+		URLTitleStyle:             URLTitleStyle,
+		MethodTitleStyle:          MethodTitleStyle,
+		SelectedItemStyle:         SelectedItemStyle,
+		ActiveInputStyle:          ActiveInputStyle,
+		InactiveInputStyle:        InactiveInputStyle,
+		DropdownItemStyle:         DropdownItemStyle,         // Initialize new style
+		DropdownSelectedItemStyle: DropdownSelectedItemStyle, // Initialize new style
+		InputContainerStyle:       InputContainerStyle,
+		DropdownTextStyle:         DropdownTextStyle,
+		DropdownArrowStyle:        DropdownArrowStyle,
+		ToastStyle:                ToastStyle,
 
-// Theme struct to hold all application styles
-type Theme struct {
-	PrimaryColor        lipgloss.Color
-	SecondaryColor      lipgloss.Color
-	URLColor            lipgloss.Color
-	MethodColor         lipgloss.Color
-	ErrorColor          lipgloss.Color
-	BrightYellow        lipgloss.Color
-	BorderStyle         lipgloss.Style
-	ActiveBorderStyle   lipgloss.Style
-	TitleStyle          lipgloss.Style
-	URLTitleStyle       lipgloss.Style
-	MethodTitleStyle    lipgloss.Style
-	SelectedItemStyle   lipgloss.Style
-	ActiveInputStyle    lipgloss.Style
-	InactiveInputStyle  lipgloss.Style
-	DropdownItemStyle lipgloss.Style // New style for dropdown items
-	DropdownSelectedItemStyle lipgloss.Style // New style for selected dropdown items
-	InputContainerStyle lipgloss.Style
-	DropdownTextStyle   lipgloss.Style
-	DropdownArrowStyle  lipgloss.Style
-	ToastStyle          lipgloss.Style
+		// Initialize new fields
+		HelpTextColor: p.HelpText,
+		ErrorStyle:    lipgloss.NewStyle().Foreground(ErrorColor),
+		SuccessStyle:  lipgloss.NewStyle().Foreground(BrightYellow),
+		SpinnerStyle:  lipgloss.NewStyle().Foreground(PrimaryColor),
+		HelpTextStyle: lipgloss.NewStyle().Foreground(p.HelpText),
+	}
+}
 
-	// New fields for additional colors and styles
-	HelpTextColor          lipgloss.Color // Color for help text
-	ErrorStyle          lipgloss.Style
-	SuccessStyle        lipgloss.Style
-	SpinnerStyle        lipgloss.Style
-	HelpTextStyle       lipgloss.Style // New style for help text
+// UsePalette rebuilds all styles and DefaultTheme from the given palette.
+// It must be called before the UI components are created, since they copy styles on construction.
+func UsePalette(p Palette) {
+	buildStyles(p)
 }
 
-// DefaultTheme is the instance of Theme with default styles
-var DefaultTheme = Theme{
-	PrimaryColor:        PrimaryColor,
-	SecondaryColor:      SecondaryColor,
-	URLColor:            URLColor,
-	MethodColor:         MethodColor,
-	ErrorColor:          ErrorColor,
-	BrightYellow:        BrightYellow,
-	BorderStyle:         BorderStyle,
-	ActiveBorderStyle:   ActiveBorderStyle,
-	TitleStyle:          TitleStyle,
-	URLTitleStyle:       URLTitleStyle,
-	MethodTitleStyle:    MethodTitleStyle,
-	SelectedItemStyle:   SelectedItemStyle,
-	ActiveInputStyle:    ActiveInputStyle,
-	InactiveInputStyle:  InactiveInputStyle,
-	DropdownItemStyle: DropdownItemStyle, // Initialize new style
-	DropdownSelectedItemStyle: DropdownSelectedItemStyle, // Initialize new style
-	InputContainerStyle: InputContainerStyle,
-	DropdownTextStyle:   DropdownTextStyle,
-	DropdownArrowStyle:  DropdownArrowStyle,
-	ToastStyle:          ToastStyle,
-
-	// Initialize new fields
-	HelpTextColor:          lipgloss.Color("#E5C07B"), // Yellow for help text
-	ErrorStyle:          lipgloss.NewStyle().Foreground(ErrorColor),
-	SuccessStyle:        lipgloss.NewStyle().Foreground(BrightYellow),
-	SpinnerStyle:        lipgloss.NewStyle().Foreground(PrimaryColor),
-	HelpTextStyle:       lipgloss.NewStyle().Foreground(lipgloss.Color("#E5C07B")), // Yellow for help text
+// DetectProfile returns the color profile supported by the terminal on stdout.
+// It honors the NO_COLOR and CLICOLOR_FORCE environment variables and falls back
+// to plain text when stdout is not a terminal.
+func DetectProfile() termenv.Profile {
+	return termenv.EnvColorProfile()
+}
+
+// ApplyProfile selects the rendering profile and a matching palette.
+// 16-color terminals get the ANSI palette so colors come from the terminal's own scheme
+// instead of approximations of hex values; Ascii disables styling entirely.
+func ApplyProfile(profile termenv.Profile) {
+	lipgloss.SetColorProfile(profile)
+	if profile == termenv.ANSI {
+		UsePalette(ANSIPalette)
+	} else {
+		UsePalette(TrueColorPalette)
+	}
+}
+
+// DisableColor switches all rendering to plain text.
+// Colors and text attributes (bold, italic, etc.) are dropped from every style,
+// which is useful for terminals or logs where escape codes are a problem.
+func DisableColor() {
+	ApplyProfile(termenv.Ascii)
 }
@@ -0,0 +1,68 @@
+package styles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// TestApplyProfile checks which palette each color profile selects and that styles render
+// with escape codes unless colors are disabled.
+func TestApplyProfile(t *testing.T) {
+	previous := lipgloss.ColorProfile()
+	t.Cleanup(func() { ApplyProfile(previous) })
+
+	tests := []struct {
+		name    string
+		profile termenv.Profile
+		palette Palette
+		styled  bool
+	}{
+		{"truecolor", termenv.TrueColor, TrueColorPalette, true},
+		{"256 colors", termenv.ANSI256, TrueColorPalette, true},
+		{"16 colors", termenv.ANSI, ANSIPalette, true},
+		{"no colors", termenv.Ascii, TrueColorPalette, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ApplyProfile(tt.profile)
+			if got := lipgloss.ColorProfile(); got != tt.profile {
+				t.Errorf("lipgloss profile = %v, want %v", got, tt.profile)
+			}
+			if PrimaryColor != tt.palette.Primary || DefaultTheme.ErrorColor != tt.palette.Error || DefaultTheme.HelpTextColor != tt.palette.HelpText {
+				t.Errorf("colors = %q, %q, %q, want those of the palette %+v", PrimaryColor, DefaultTheme.ErrorColor, DefaultTheme.HelpTextColor, tt.palette)
+			}
+			rendered := DefaultTheme.ErrorStyle.Render("error")
+			if styled := strings.Contains(rendered, "\x1b["); styled != tt.styled {
+				t.Errorf("ErrorStyle.Render() = %q, want styled %v", rendered, tt.styled)
+			}
+		})
+	}
+}
+
+// TestDisableColor checks that disabling colors removes colors and text attributes from
+// every kind of style, including those built from fixed colors and bold text.
+func TestDisableColor(t *testing.T) {
+	previous := lipgloss.ColorProfile()
+	t.Cleanup(func() { ApplyProfile(previous) })
+
+	ApplyProfile(termenv.TrueColor)
+	if rendered := HeaderNameStyle.Render("Name"); !strings.Contains(rendered, "\x1b[") {
+		t.Fatalf("HeaderNameStyle.Render() = %q, want it styled before colors are disabled", rendered)
+	}
+
+	DisableColor()
+	for name, style := range map[string]lipgloss.Style{
+		"HeaderNameStyle":   HeaderNameStyle,
+		"ToastStyle":        ToastStyle,
+		"ErrorStyle":        DefaultTheme.ErrorStyle,
+		"HelpTextStyle":     DefaultTheme.HelpTextStyle,
+		"SelectedItemStyle": SelectedItemStyle,
+	} {
+		if rendered := style.Render("text"); strings.Contains(rendered, "\x1b[") {
+			t.Errorf("%s.Render() = %q, want plain text", name, rendered)
+		}
+	}
+}
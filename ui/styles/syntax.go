@@ -0,0 +1,78 @@
+package styles
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SyntaxTheme holds the styles used to highlight formatted JSON bodies.
+// It is chosen independently of the palette used by the rest of the UI.
+type SyntaxTheme struct {
+	Name        string         // Name identifies the theme in the config, e.g. "high-contrast"
+	Key         lipgloss.Style // Object keys
+	String      lipgloss.Style // String values
+	Number      lipgloss.Style // Numbers
+	Literal     lipgloss.Style // true, false and null
+	Punctuation lipgloss.Style // Braces, brackets, commas and colons
+	Comment     lipgloss.Style // Annotations added by LazyPost, e.g. array indexes
+}
+
+// SyntaxThemes lists the available syntax themes; the first one is the default.
+var SyntaxThemes = []SyntaxTheme{
+	{
+		Name:        "default",
+		Key:         lipgloss.NewStyle().Foreground(lipgloss.Color("#61AFEF")),
+		String:      lipgloss.NewStyle().Foreground(lipgloss.Color("#98C379")),
+		Number:      lipgloss.NewStyle().Foreground(lipgloss.Color("#D19A66")),
+		Literal:     lipgloss.NewStyle().Foreground(lipgloss.Color("#C678DD")),
+		Punctuation: lipgloss.NewStyle().Foreground(lipgloss.Color("#ABB2BF")),
+		Comment:     lipgloss.NewStyle().Foreground(lipgloss.Color("#7F848E")).Italic(true),
+	},
+	{
+		// Saturated, bold colors that stay readable on washed-out projectors
+		Name:        "high-contrast",
+		Key:         lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true),
+		String:      lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")),
+		Number:      lipgloss.NewStyle().Foreground(lipgloss.Color("#00FFFF")).Bold(true),
+		Literal:     lipgloss.NewStyle().Foreground(lipgloss.Color("#FF00FF")).Bold(true),
+		Punctuation: lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true),
+		Comment:     lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Underline(true),
+	},
+	{
+		Name: "none",
+	},
+}
+
+// Syntax is the syntax theme used for response bodies.
+var Syntax = SyntaxThemes[0]
+
+// UseSyntaxTheme selects the syntax theme called name. An empty name selects the default.
+func UseSyntaxTheme(name string) error {
+	if name == "" {
+		Syntax = SyntaxThemes[0]
+		return nil
+	}
+	names := make([]string, len(SyntaxThemes))
+	for i, theme := range SyntaxThemes {
+		if theme.Name == name {
+			Syntax = theme
+			return nil
+		}
+		names[i] = theme.Name
+	}
+	return fmt.Errorf("unknown syntax theme %q: use one of %s", name, strings.Join(names, ", "))
+}
+
+// NextSyntaxTheme selects the theme after the current one, wrapping around, and returns it.
+func NextSyntaxTheme() SyntaxTheme {
+	for i, theme := range SyntaxThemes {
+		if theme.Name == Syntax.Name {
+			Syntax = SyntaxThemes[(i+1)%len(SyntaxThemes)]
+			return Syntax
+		}
+	}
+	Syntax = SyntaxThemes[0]
+	return Syntax
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/ui/styles"
+)
+
+// splitTrailers moves the fields named by a Trailer header out of headers, so they can be
+// sent as trailers after a chunked body. The Trailer header itself is dropped, since the
+// transport writes it from the trailers. Declared fields without a value are declared but
+// not sent. It returns nil trailers, and headers unchanged, when none are declared.
+func splitTrailers(headers map[string]string) (map[string]string, http.Header) {
+	declared := ""
+	for name, value := range headers {
+		if http.CanonicalHeaderKey(name) == "Trailer" {
+			declared = value
+		}
+	}
+	if strings.TrimSpace(declared) == "" {
+		return headers, nil
+	}
+
+	trailers := make(http.Header)
+	for _, name := range strings.Split(declared, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			trailers[http.CanonicalHeaderKey(name)] = nil
+		}
+	}
+	remaining := make(map[string]string, len(headers))
+	for name, value := range headers {
+		canonical := http.CanonicalHeaderKey(name)
+		if _, ok := trailers[canonical]; ok {
+			trailers[canonical] = []string{value}
+			continue
+		}
+		if canonical != "Trailer" {
+			remaining[name] = value
+		}
+	}
+	return remaining, trailers
+}
+
+// formatTrailers renders the trailers received after the response body, sorted by name.
+// Trailers the server declared but did not send are listed as missing.
+func formatTrailers(trailers http.Header) string {
+	if len(trailers) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(trailers))
+	for name := range trailers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var text strings.Builder
+	text.WriteString("\n" + styles.HeaderNameStyle.Render("Trailers:") + "\n")
+	for _, name := range names {
+		if len(trailers[name]) == 0 {
+			text.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render(name+":"), styles.DefaultTheme.HelpTextStyle.Render("(declared, not received)")))
+			continue
+		}
+		for _, value := range trailers[name] {
+			text.WriteString(fmt.Sprintf("%s %s\n", styles.HeaderNameStyle.Render(name+":"), value))
+		}
+	}
+	return text.String()
+}
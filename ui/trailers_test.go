@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/fixtures"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// TestSplitTrailers tests that the fields named by a Trailer header are moved out of the headers.
+func TestSplitTrailers(t *testing.T) {
+	headers, trailers := splitTrailers(map[string]string{
+		"Content-Type": "application/json",
+		"trailer":      "x-checksum, X-Missing",
+		"X-Checksum":   "abc",
+	})
+	if !reflect.DeepEqual(headers, map[string]string{"Content-Type": "application/json"}) {
+		t.Errorf("headers = %v, want only Content-Type", headers)
+	}
+	want := http.Header{"X-Checksum": {"abc"}, "X-Missing": nil}
+	if !reflect.DeepEqual(trailers, want) {
+		t.Errorf("trailers = %v, want %v", trailers, want)
+	}
+
+	headers, trailers = splitTrailers(map[string]string{"Accept": "*/*"})
+	if trailers != nil || len(headers) != 1 {
+		t.Errorf("got %v and trailers %v without a Trailer header, want the headers unchanged", headers, trailers)
+	}
+}
+
+// TestSendRequestTrailers tests that declared trailers follow a chunked body and that
+// response trailers are captured and shown in their own section.
+func TestSendRequestTrailers(t *testing.T) {
+	server := fixtures.NewServer(t)
+
+	headers := map[string]string{"Trailer": "X-Checksum", "X-Checksum": "abc"}
+	resp, err := sendRequest(context.Background(), "POST", server.URL+"/trailers", headers, "payload", proxyRoute{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	last, _ := server.LastRequest()
+	if last.Trailer.Get("X-Checksum") != "abc" || last.Header.Get("X-Checksum") != "" {
+		t.Errorf("server got header %q and trailer %q, want only the trailer", last.Header.Get("X-Checksum"), last.Trailer.Get("X-Checksum"))
+	}
+	if string(last.Body) != "payload" {
+		t.Errorf("server got body %q, want payload", last.Body)
+	}
+
+	if resp.Trailer.Get("Grpc-Status") != "0" || resp.Trailer.Get("X-Checksum") != "abc" {
+		t.Errorf("response trailers = %v, want Grpc-Status and X-Checksum", resp.Trailer)
+	}
+	formatted := ansi.Strip(formatResponseHeaders(resp, preparedRequest{}))
+	if !strings.Contains(formatted, "Trailers:\nGrpc-Status: 0\nX-Checksum: abc\n") {
+		t.Errorf("headers view does not list the trailers:\n%s", formatted)
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"encoding/json" // Added import
 	"regexp"
+	"strings"
 )
 
 // validateURL checks if the provided string is a valid URL.
@@ -18,6 +19,7 @@ func validateURL(url string) bool {
 	// - HTTP and HTTPS protocols only
 	// - Domain names with hyphens (including consecutive hyphens)
 	// - Valid TLDs (2 or more characters)
+	// - localhost and IPv4 addresses, for local development servers
 	// - Optional port numbers (1-5 digits, limited to 0-65535)
 	// - Optional path (no unencoded spaces)
 	// - Optional query parameters
@@ -29,7 +31,7 @@ func validateURL(url string) bool {
 	}
 
 	// Basic URL regex pattern without space validation
-	pattern := `^(http|https)://[a-zA-Z0-9]+([-\.][a-zA-Z0-9-]+)*\.[a-zA-Z]{2,}(:[0-9]{1,5})?(\/[^?#]*)?(\?[^#]*)?(#.*)?$`
+	pattern := `^(http|https)://([a-zA-Z0-9]+([-\.][a-zA-Z0-9-]+)*\.[a-zA-Z]{2,}|localhost|[0-9]{1,3}(\.[0-9]{1,3}){3})(:[0-9]{1,5})?(\/[^?#]*)?(\?[^#]*)?(#.*)?$`
 	matched, _ := regexp.MatchString(pattern, url)
 	if !matched {
 		return false
@@ -76,3 +78,18 @@ func IsValidJSON(s string) bool {
 	}
 	return json.Valid([]byte(s))
 }
+
+// checkFields reports whether the form's fields pass validation, showing the problems
+// in a toast if not. The fields themselves are marked in the Query tab.
+func (a *App) checkFields() bool {
+	errs := a.tabContainer.GetQueryTab().FieldErrors()
+	if len(errs) == 0 {
+		return true
+	}
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = err.Error()
+	}
+	a.toast.Show("Request not sent. Fix these fields first:\n" + strings.Join(lines, "\n"))
+	return false
+}
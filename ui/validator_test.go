@@ -72,6 +72,21 @@ func TestValidateURL(t *testing.T) {
 			url:      "https://sub.example123.com:8443/path/to/resource?param=value#section",
 			expected: true,
 		},
+		{
+			name:     "IP address",
+			url:      "http://192.168.1.1",
+			expected: true, // IPv4 addresses are allowed for local development
+		},
+		{
+			name:     "Localhost",
+			url:      "http://localhost",
+			expected: true, // localhost is allowed for local development
+		},
+		{
+			name:     "Localhost with port and path",
+			url:      "http://localhost:8080/api",
+			expected: true,
+		},
 
 		// Invalid URLs
 		{
@@ -89,11 +104,6 @@ func TestValidateURL(t *testing.T) {
 			url:      "ftp://example.com",
 			expected: false,
 		},
-		{
-			name:     "IP address instead of domain",
-			url:      "http://192.168.1.1",
-			expected: false, // IP addresses not supported by the current regex
-		},
 		{
 			name:     "Missing domain",
 			url:      "http://",
@@ -105,9 +115,9 @@ func TestValidateURL(t *testing.T) {
 			expected: false,
 		},
 		{
-			name:     "Local domain without TLD",
-			url:      "http://localhost",
-			expected: false, // Local domains not supported by the current regex
+			name:     "Other local name without TLD",
+			url:      "http://devbox",
+			expected: false,
 		},
 		{
 			name:     "Invalid characters in domain",
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RAshkettle/LazyPost/env"
+)
+
+// variableLint reports problems with the {{name}} placeholders of a request.
+type variableLint struct {
+	unresolved  []string          // Placeholders without a variable in the selected environment, in order of appearance
+	unused      []string          // Variables of the selected environment the request does not reference
+	suggestions map[string]string // Closest defined variable for each unresolved placeholder that looks like a typo
+}
+
+// requestTemplates returns the URL, parameters and header values of the form as entered,
+// before variables are substituted.
+func (a *App) requestTemplates() []string {
+	queryTab := a.tabContainer.GetQueryTab()
+	templates := []string{a.urlInput.GetText()}
+	for name, value := range queryTab.ParamsInput.GetParams() {
+		templates = append(templates, name, value)
+	}
+	for _, value := range queryTab.HeadersInput.GetHeaders() {
+		templates = append(templates, value)
+	}
+	for _, value := range queryTab.AuthInput.GetAuthHeaders() {
+		templates = append(templates, value)
+	}
+	// A body sent as entered keeps its placeholders on purpose
+	if !a.dataBinary {
+		templates = append(templates, queryTab.GetBodyContent())
+	}
+	return templates
+}
+
+// lintVariables checks the placeholders in templates against e, which may be nil when
+// no environment is selected.
+func lintVariables(e *env.Environment, templates []string) variableLint {
+	lint := variableLint{suggestions: make(map[string]string)}
+
+	used := make(map[string]bool)
+	for _, template := range templates {
+		for _, name := range env.Placeholders(template) {
+			if used[name] {
+				continue
+			}
+			used[name] = true
+			if _, ok := e.Lookup(name); !ok {
+				lint.unresolved = append(lint.unresolved, name)
+			}
+		}
+	}
+
+	if e == nil {
+		return lint
+	}
+	for _, v := range e.Variables {
+		if !used[v.Name] {
+			lint.unused = append(lint.unused, v.Name)
+		}
+	}
+
+	// Suggest the closest defined name, so {{basUrl}} points at {{baseUrl}}
+	for _, name := range lint.unresolved {
+		best, bestDistance := "", 3 // Only names at most two edits away are suggested
+		for _, v := range e.Variables {
+			if d := editDistance(strings.ToLower(name), strings.ToLower(v.Name)); d < bestDistance {
+				best, bestDistance = v.Name, d
+			}
+		}
+		if best != "" {
+			lint.suggestions[name] = best
+		}
+	}
+	return lint
+}
+
+// unresolvedMessage describes the unresolved placeholders, with suggestions for likely typos.
+func (l variableLint) unresolvedMessage(environment string) string {
+	parts := make([]string, len(l.unresolved))
+	for i, name := range l.unresolved {
+		parts[i] = "{{" + name + "}}"
+		if suggestion, ok := l.suggestions[name]; ok {
+			parts[i] += fmt.Sprintf(" (did you mean {{%s}}?)", suggestion)
+		}
+	}
+	where := "no environment is selected"
+	if environment != "" {
+		where = "not defined in " + environment
+	}
+	return fmt.Sprintf("Request not sent. Unresolved variables, %s: %s", where, strings.Join(parts, ", "))
+}
+
+// checkVariables lints the request's placeholders and shows a toast if any cannot be resolved.
+// It reports whether the request may be sent.
+func (a *App) checkVariables() bool {
+	// A request pinned to a missing environment must not fall back to another one
+	if a.pinnedEnvironment != "" && a.requestEnvironment() == nil {
+		a.toast.Show(fmt.Sprintf("Request not sent. It is pinned to the environment %q, which does not exist", a.pinnedEnvironment))
+		return false
+	}
+
+	lint := lintVariables(a.requestEnvironment(), a.requestTemplates())
+	if len(lint.unresolved) == 0 {
+		return true
+	}
+
+	environment := ""
+	if current := a.requestEnvironment(); current != nil {
+		environment = current.Name
+	}
+	a.toast.Show(lint.unresolvedMessage(environment))
+	return false
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
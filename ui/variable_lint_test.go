@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/RAshkettle/LazyPost/env"
+)
+
+// TestLintVariables checks unresolved and unused variables and typo suggestions.
+func TestLintVariables(t *testing.T) {
+	e := &env.Environment{Name: "dev", Variables: []env.Variable{
+		{Name: "baseUrl", Value: "http://localhost"},
+		{Name: "token", Value: "abc"},
+		{Name: "unusedVar", Value: "x"},
+	}}
+
+	lint := lintVariables(e, []string{"{{basUrl}}/users", "Bearer {{token}}", "{{basUrl}}", "{{missing}}"})
+
+	if want := []string{"basUrl", "missing"}; !reflect.DeepEqual(lint.unresolved, want) {
+		t.Errorf("unresolved = %v, want %v", lint.unresolved, want)
+	}
+	if want := []string{"baseUrl", "unusedVar"}; !reflect.DeepEqual(lint.unused, want) {
+		t.Errorf("unused = %v, want %v", lint.unused, want)
+	}
+	if got := lint.suggestions["basUrl"]; got != "baseUrl" {
+		t.Errorf("suggestion for basUrl = %q, want baseUrl", got)
+	}
+	if _, ok := lint.suggestions["missing"]; ok {
+		t.Error("no suggestion expected for a name unlike any variable")
+	}
+
+	message := lint.unresolvedMessage("dev")
+	if !strings.Contains(message, "{{basUrl}} (did you mean {{baseUrl}}?)") {
+		t.Errorf("message %q does not suggest baseUrl", message)
+	}
+
+	// Without an environment every placeholder is unresolved and nothing is unused
+	lint = lintVariables(nil, []string{"{{a}}", "plain"})
+	if len(lint.unresolved) != 1 || len(lint.unused) != 0 {
+		t.Errorf("nil environment: got %+v", lint)
+	}
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/RAshkettle/LazyPost/env"
+	"github.com/RAshkettle/LazyPost/ui/styles"
+	"github.com/atotto/clipboard"
+)
+
+// SetEnvironments sets the environments whose variables are substituted into {{name}} placeholders.
+// path is where the environment editor saves them; if empty, edits are not saved.
+func (a *App) SetEnvironments(store env.Store, path string) {
+	a.environments = store
+	a.environmentsPath = path
+}
+
+// requestEnvironment returns the environment the current request is sent with: the one it
+// is pinned to, if any, otherwise the selected one. It returns nil when there is none,
+// including when the pinned environment no longer exists.
+func (a *App) requestEnvironment() *env.Environment {
+	if a.pinnedEnvironment != "" {
+		return a.environments.Find(a.pinnedEnvironment)
+	}
+	return a.environments.Current()
+}
+
+// requestURL returns the URL entered in the form with environment variables substituted.
+func (a *App) requestURL() string {
+	return a.requestEnvironment().Expand(a.urlInput.GetText())
+}
+
+// expandValues returns a copy of values with environment variables substituted into each value.
+func expandValues(e *env.Environment, values map[string]string) map[string]string {
+	expanded := make(map[string]string, len(values))
+	for k, v := range values {
+		expanded[k] = e.Expand(v)
+	}
+	return expanded
+}
+
+// expandParams returns a copy of params with environment variables substituted into each
+// name and value, since the parameter names are linted for placeholders too.
+func expandParams(e *env.Environment, params map[string]string) map[string]string {
+	expanded := make(map[string]string, len(params))
+	for k, v := range params {
+		expanded[e.Expand(k)] = e.Expand(v)
+	}
+	return expanded
+}
+
+// focusedText returns the text and cursor position of the focused URL, parameter,
+// header value or body field, if any.
+func (a *App) focusedText() (string, int, bool) {
+	if a.urlInput.Active {
+		return a.urlInput.TextInput.Value(), a.urlInput.TextInput.Position(), true
+	}
+	if a.tabContainer.Active && a.tabContainer.ActiveTab == 0 {
+		return a.tabContainer.QueryTab.FocusedText()
+	}
+	return "", 0, false
+}
+
+// insertText inserts text at the cursor of the focused URL, parameter, header value
+// or body. If no text field is focused, the text is copied to the clipboard instead.
+func (a *App) insertText(text string) {
+	if a.urlInput.Active {
+		a.urlInput.InsertText(text)
+		return
+	}
+	if a.tabContainer.Active && a.tabContainer.ActiveTab == 0 && a.tabContainer.QueryTab.InsertText(text) {
+		return
+	}
+
+	if err := clipboard.WriteAll(text); err != nil {
+		a.toast.Show(fmt.Sprintf("Error copying to clipboard: %v", err))
+		return
+	}
+	a.toast.Show("No text field is focused; copied " + text + " to the clipboard")
+}
+
+// variablePeek describes the variable placeholder under the cursor, with its value masked
+// if it is a secret. It returns "" when the cursor is not on a placeholder.
+func (a *App) variablePeek() string {
+	text, cursor, ok := a.focusedText()
+	if !ok {
+		return ""
+	}
+	name, ok := env.PlaceholderAt(text, cursor)
+	if !ok {
+		return ""
+	}
+
+	current := a.requestEnvironment()
+	if current == nil {
+		return fmt.Sprintf("{{%s}} is not resolved: no environment selected", name)
+	}
+	v, ok := current.Lookup(name)
+	if !ok {
+		return fmt.Sprintf("{{%s}} is not defined in %s", name, current.Name)
+	}
+	return fmt.Sprintf("{{%s}} = %s", name, v.DisplayValue())
+}
+
+// renderStatusBar renders the selected environment, the variable under the cursor and the
+// modes that change how requests are handled.
+// It returns "" when there is nothing to show.
+func (a *App) renderStatusBar() string {
+	status := ""
+	if a.pinnedEnvironment != "" {
+		status = "Environment: " + a.pinnedEnvironment + " [PINNED to this request]"
+		if a.requestEnvironment() == nil {
+			status += " (missing, sending is blocked)"
+		}
+	} else if current := a.requestEnvironment(); current != nil {
+		status = "Environment: " + current.Name
+	}
+	if a.config.ReadOnly {
+		if status != "" {
+			status = " • " + status
+		}
+		status = "READ-ONLY" + status
+	}
+	if a.privacyMode {
+		if status != "" {
+			status = " • " + status
+		}
+		status = "PRIVACY" + status
+	}
+	if peek := a.variablePeek(); peek != "" {
+		if status != "" {
+			status += " • "
+		}
+		status += peek
+	}
+	if a.dataBinary {
+		if status != "" {
+			status += " • "
+		}
+		status += "Body: exact bytes"
+	}
+	if a.rawEncoding {
+		if status != "" {
+			status += " • "
+		}
+		status += "Encoding: raw"
+	}
+	if a.watching {
+		if status != "" {
+			status += " • "
+		}
+		status += "Watching files"
+	}
+	if a.recording != nil {
+		if status != "" {
+			status += " • "
+		}
+		status += fmt.Sprintf("Recording session (%d requests) • Alt+K to export", len(a.recording.steps))
+	}
+	if a.tabContainer.HasUnseenResult() {
+		if status != "" {
+			status += " • "
+		}
+		status += "New response • Alt+4 to view"
+	}
+	if status == "" {
+		return ""
+	}
+	return styles.DefaultTheme.HelpTextStyle.Render(status)
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"github.com/RAshkettle/LazyPost/vault"
+)
+
+// requestHasVaultPlaceholders reports whether the URL, a parameter value or a header value
+// references a Vault secret.
+func requestHasVaultPlaceholders(rawURL string, params, headers map[string]string) bool {
+	if vault.HasPlaceholders(rawURL) {
+		return true
+	}
+	for _, value := range params {
+		if vault.HasPlaceholders(value) {
+			return true
+		}
+	}
+	for _, value := range headers {
+		if vault.HasPlaceholders(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveVaultPlaceholders replaces Vault placeholders in the URL, parameter values and
+// header values, and returns the final URL with parameters applied and the resolved
+// headers. params and headers are left untouched, so the secrets are only held by the
+// request that is sent, never by the prepared request shown in the UI.
+func resolveVaultPlaceholders(address, rawURL string, params, headers map[string]string) (string, map[string]string, error) {
+	client := vault.NewClient(address)
+
+	resolvedURL, err := client.Resolve(rawURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	resolvedParams := make(map[string]string, len(params))
+	for name, value := range params {
+		if resolvedParams[name], err = client.Resolve(value); err != nil {
+			return "", nil, err
+		}
+	}
+
+	resolvedHeaders := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if resolvedHeaders[name], err = client.Resolve(value); err != nil {
+			return "", nil, err
+		}
+	}
+
+	finalURL, err := buildURLWithParams(resolvedURL, resolvedParams)
+	if err != nil {
+		return "", nil, err
+	}
+	return finalURL, resolvedHeaders, nil
+}
@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/env"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// watchInterval is how often watched files are checked for changes.
+	watchInterval = 250 * time.Millisecond
+	// watchDebounce is how long a changed file must stay unchanged before the request is
+	// resent, so an editor saving in several writes triggers a single send.
+	watchDebounce = 500 * time.Millisecond
+)
+
+// watchTickMsg checks the watched files. id ties it to the watch that scheduled it,
+// so ticks of a watch that was turned off are ignored.
+type watchTickMsg struct {
+	id int
+}
+
+// fileStamp identifies a version of a file by its modification time and size.
+type fileStamp struct {
+	modTime time.Time // Last modification time, zero if the file does not exist
+	size    int64     // Size in bytes
+}
+
+// statFile returns the current stamp of the file at path.
+func statFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// fileWatch polls files for changes. The zero value watches nothing.
+type fileWatch struct {
+	stamps  map[string]fileStamp // Last seen stamp of each watched file
+	changed map[string]bool      // Files changed since the last resend
+	settled time.Time            // When the last change was seen
+}
+
+// start begins watching paths, taking their current versions as unchanged.
+func (w *fileWatch) start(paths []string) {
+	w.stamps = make(map[string]fileStamp, len(paths))
+	w.changed = make(map[string]bool)
+	for _, path := range paths {
+		w.stamps[path] = statFile(path)
+	}
+}
+
+// sync takes the current version of path as unchanged, e.g. after LazyPost saved it itself.
+func (w *fileWatch) sync(path string) {
+	if _, ok := w.stamps[path]; ok {
+		w.stamps[path] = statFile(path)
+		delete(w.changed, path)
+	}
+}
+
+// remove stops watching path.
+func (w *fileWatch) remove(path string) {
+	delete(w.stamps, path)
+	delete(w.changed, path)
+}
+
+// poll records files that changed since the last poll and returns the changed files once
+// none has changed for watchDebounce, clearing them. It returns nil until then.
+func (w *fileWatch) poll(now time.Time) []string {
+	for path, stamp := range w.stamps {
+		if current := statFile(path); current != stamp {
+			w.stamps[path] = current
+			w.changed[path] = true
+			w.settled = now
+		}
+	}
+	if len(w.changed) == 0 || now.Sub(w.settled) < watchDebounce {
+		return nil
+	}
+
+	changed := make([]string, 0, len(w.changed))
+	for path := range w.changed {
+		changed = append(changed, path)
+	}
+	sort.Strings(changed)
+	w.changed = make(map[string]bool)
+	return changed
+}
+
+// SetBodyFile records the file the body was read from, e.g. with --body @file, so watch
+// mode reloads the body when the file changes. Loading another request forgets it.
+func (a *App) SetBodyFile(path string) {
+	a.bodyFile = path
+}
+
+// watchedFiles returns the files the current request depends on: its body file and the
+// environments file, if any.
+func (a *App) watchedFiles() []string {
+	var paths []string
+	if a.bodyFile != "" {
+		paths = append(paths, a.bodyFile)
+	}
+	if a.environmentsPath != "" {
+		paths = append(paths, a.environmentsPath)
+	}
+	return paths
+}
+
+// handleToggleWatch switches watch mode, in which the request is resent whenever its body
+// file or the environments file changes on disk.
+func (a *App) handleToggleWatch() tea.Cmd {
+	if a.watching {
+		a.watching = false
+		a.watch = fileWatch{}
+		a.toast.Show("Stopped watching files")
+		return nil
+	}
+
+	paths := a.watchedFiles()
+	if len(paths) == 0 {
+		a.toast.Show("No files to watch: start with --body @file or keep environments in the config directory")
+		return nil
+	}
+	a.watching = true
+	a.watchID++
+	a.watch.start(paths)
+
+	names := make([]string, len(paths))
+	for i, path := range paths {
+		names[i] = filepath.Base(path)
+	}
+	a.toast.Show(fmt.Sprintf("Watching %s: saving changes resends the request", strings.Join(names, ", ")))
+	return a.scheduleWatchTick()
+}
+
+// scheduleWatchTick schedules the next check of the watched files.
+func (a *App) scheduleWatchTick() tea.Cmd {
+	id := a.watchID
+	return tea.Tick(watchInterval, func(time.Time) tea.Msg {
+		return watchTickMsg{id: id}
+	})
+}
+
+// handleWatchTick checks the watched files and, once changes have settled, reloads them
+// and resends the request. A resend is held back while another request is running.
+func (a *App) handleWatchTick(msg watchTickMsg) tea.Cmd {
+	if !a.watching || msg.id != a.watchID {
+		return nil
+	}
+	if a.jobs.running() {
+		return a.scheduleWatchTick()
+	}
+
+	changed := a.watch.poll(time.Now())
+	if len(changed) == 0 {
+		return a.scheduleWatchTick()
+	}
+	for _, path := range changed {
+		if err := a.reloadWatchedFile(path); err != nil {
+			a.toast.Show(fmt.Sprintf("Not resending: %v", err))
+			return a.scheduleWatchTick()
+		}
+	}
+
+	cmd := a.handleSubmit()
+	a.keepQueryFocus = true // Resent in the background, so do not interrupt editing
+	return tea.Batch(cmd, a.scheduleWatchTick())
+}
+
+// reloadWatchedFile loads the changed file at path into the body or the environments.
+func (a *App) reloadWatchedFile(path string) error {
+	switch path {
+	case a.bodyFile:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading body: %w", err)
+		}
+		a.setBody(string(data))
+	case a.environmentsPath:
+		if a.environmentEditor.Visible {
+			return nil // Saving the editor on close overwrites the file anyway
+		}
+		store, err := env.Load(path)
+		if err != nil {
+			return err
+		}
+		a.environments = store
+	}
+	return nil
+}
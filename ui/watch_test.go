@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/RAshkettle/LazyPost/config"
+)
+
+// TestFileWatchPoll tests that a change is reported once it has settled for watchDebounce,
+// and that a file saved by LazyPost itself is not reported.
+func TestFileWatchPoll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "body.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var w fileWatch
+	w.start([]string{path})
+
+	now := time.Now()
+	if changed := w.poll(now); changed != nil {
+		t.Fatalf("poll() = %v before any change, want nil", changed)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"id": 1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if changed := w.poll(now); changed != nil {
+		t.Errorf("poll() = %v right after a change, want nil until it settles", changed)
+	}
+	if changed := w.poll(now.Add(watchDebounce)); !reflect.DeepEqual(changed, []string{path}) {
+		t.Errorf("poll() = %v after the debounce, want [%s]", changed, path)
+	}
+	if changed := w.poll(now.Add(2 * watchDebounce)); changed != nil {
+		t.Errorf("poll() = %v after reporting, want nil", changed)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"id": 22}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	w.sync(path)
+	if changed := w.poll(now.Add(4 * watchDebounce)); changed != nil {
+		t.Errorf("poll() = %v after sync, want nil", changed)
+	}
+}
+
+// TestReloadWatchedBody checks that a body reloaded from its file keeps the carriage
+// returns and tabs the editor can't show.
+func TestReloadWatchedBody(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "body.json")
+	body := "{\r\n\t\"id\": 1\r\n}"
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	app := NewApp(config.Config{})
+	app.SetBodyFile(path)
+	if err := app.reloadWatchedFile(path); err != nil {
+		t.Fatalf("reloadWatchedFile() error = %v", err)
+	}
+	if got := app.bodyText(); got != body {
+		t.Errorf("bodyText() = %q, want the file's content %q", got, body)
+	}
+}
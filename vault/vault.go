@@ -0,0 +1,163 @@
+// Package vault resolves {{vault:path#key}} placeholders against a HashiCorp Vault server.
+// Secrets are fetched when a request is sent and are never written to LazyPost's own storage.
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// placeholderPattern matches {{vault:secret/path#key}}; group 1 is the path, group 2 the key.
+var placeholderPattern = regexp.MustCompile(`\{\{vault:([^#{}]+)#([^{}]+)\}\}`)
+
+// HasPlaceholders reports whether s contains at least one Vault placeholder.
+func HasPlaceholders(s string) bool {
+	return placeholderPattern.MatchString(s)
+}
+
+// Placeholders returns the Vault placeholders in s, e.g. "{{vault:kv/api#token}}", in order
+// of appearance.
+func Placeholders(s string) []string {
+	return placeholderPattern.FindAllString(s, -1)
+}
+
+// Client reads secrets from a Vault server. Secrets are cached for the lifetime
+// of the Client, so a Client should be created for each request that is sent.
+type Client struct {
+	Address    string                    // Address is the base URL of the Vault server, e.g. "https://vault:8200".
+	Token      string                    // Token is sent as X-Vault-Token.
+	Namespace  string                    // Namespace, if set, is sent as X-Vault-Namespace (Vault Enterprise).
+	HTTPClient *http.Client              // HTTPClient performs the secret reads.
+	cache      map[string]map[string]any // cache holds secret data by path.
+}
+
+// NewClient returns a Client for address, falling back to the VAULT_ADDR environment variable
+// when address is empty. The token is taken from VAULT_TOKEN or, like the vault CLI,
+// from ~/.vault-token.
+func NewClient(address string) *Client {
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			if data, err := os.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+				token = strings.TrimSpace(string(data))
+			}
+		}
+	}
+
+	return &Client{
+		Address:    strings.TrimRight(address, "/"),
+		Token:      token,
+		Namespace:  os.Getenv("VAULT_NAMESPACE"),
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[string]map[string]any),
+	}
+}
+
+// Resolve replaces every Vault placeholder in s with the referenced secret value.
+// Text without placeholders is returned unchanged and does not contact the server.
+func (c *Client) Resolve(s string) (string, error) {
+	var resolveErr error
+
+	resolved := placeholderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+		if resolveErr != nil {
+			return placeholder
+		}
+		parts := placeholderPattern.FindStringSubmatch(placeholder)
+		value, err := c.secretValue(strings.TrimSpace(parts[1]), strings.TrimSpace(parts[2]))
+		if err != nil {
+			resolveErr = err
+			return placeholder
+		}
+		return value
+	})
+
+	if resolveErr != nil {
+		return s, resolveErr
+	}
+	return resolved, nil
+}
+
+// secretValue returns the value of key in the secret at path.
+func (c *Client) secretValue(path, key string) (string, error) {
+	data, err := c.readSecret(path)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no key %q", path, key)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	// Non-string values (numbers, objects) are inserted as JSON
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// readSecret fetches the secret at path, unwrapping KV version 2 responses.
+func (c *Client) readSecret(path string) (map[string]any, error) {
+	if data, ok := c.cache[path]; ok {
+		return data, nil
+	}
+
+	if c.Address == "" {
+		return nil, errors.New("vault placeholder used but no Vault address is configured (set VAULT_ADDR)")
+	}
+	if c.Token == "" {
+		return nil, errors.New("vault placeholder used but no Vault token is available (set VAULT_TOKEN)")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.Address+"/v1/"+strings.TrimLeft(path, "/"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", c.Token)
+	if c.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.Namespace)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("reading vault secret %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reading vault secret %s: %s", path, resp.Status)
+	}
+
+	var payload struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("reading vault secret %s: %w", path, err)
+	}
+
+	data := payload.Data
+	// KV version 2 nests the secret under data.data next to data.metadata
+	if inner, ok := data["data"].(map[string]any); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = inner
+		}
+	}
+
+	c.cache[path] = data
+	return data, nil
+}
@@ -0,0 +1,92 @@
+package vault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// newTestServer returns a Vault stand-in serving one KV v1 and one KV v2 secret,
+// and a counter of the requests it received.
+func newTestServer(t *testing.T) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/kv/api":
+			w.Write([]byte(`{"data": {"token": "abc", "port": 8080}}`))
+		case "/v1/secret/data/app":
+			w.Write([]byte(`{"data": {"data": {"password": "hunter2"}, "metadata": {"version": 3}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// TestResolve tests placeholder substitution for KV v1 and v2 secrets.
+func TestResolve(t *testing.T) {
+	server, requests := newTestServer(t)
+	t.Setenv("VAULT_TOKEN", "test-token")
+	client := NewClient(server.URL)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "No placeholders", input: "Bearer xyz", expected: "Bearer xyz"},
+		{name: "KV v1", input: "Bearer {{vault:kv/api#token}}", expected: "Bearer abc"},
+		{name: "KV v2", input: "{{vault:secret/data/app#password}}", expected: "hunter2"},
+		{name: "Non-string value", input: "port={{vault:kv/api#port}}", expected: "port=8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Resolve(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Resolve(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	if *requests != 2 {
+		t.Errorf("expected secrets to be cached per path, got %d requests", *requests)
+	}
+}
+
+// TestResolveErrors tests missing keys, missing secrets and missing configuration.
+func TestResolveErrors(t *testing.T) {
+	server, _ := newTestServer(t)
+	t.Setenv("VAULT_TOKEN", "test-token")
+	client := NewClient(server.URL)
+
+	for _, input := range []string{"{{vault:kv/api#missing}}", "{{vault:kv/missing#token}}"} {
+		if _, err := client.Resolve(input); err == nil {
+			t.Errorf("Resolve(%q): expected an error", input)
+		}
+	}
+
+	t.Setenv("VAULT_ADDR", "")
+	if _, err := NewClient("").Resolve("{{vault:kv/api#token}}"); err == nil {
+		t.Error("expected an error without a Vault address")
+	}
+}
+
+// TestPlaceholders tests that placeholders are listed in order, ignoring other braces.
+func TestPlaceholders(t *testing.T) {
+	got := Placeholders("{{vault:kv/api#token}}:{{name}}:{{vault:secret/data/app#password}}")
+	want := []string{"{{vault:kv/api#token}}", "{{vault:secret/data/app#password}}"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Placeholders() = %q, want %q", got, want)
+	}
+}